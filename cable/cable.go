@@ -0,0 +1,181 @@
+// Package cable is a minimal ActionCable client subscribing to a single
+// channel per connection.
+//
+// It replaces github.com/squarescale/actioncable-go, which reads the
+// websocket from several goroutines at once, prints to the standard output,
+// drops the messages no one is ready to receive and keys its subscriptions
+// by channel name only, so that the same channel of two projects can not be
+// followed at once.
+package cable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/squarescale/logger"
+)
+
+// Connection tuning: the server pings every 3 seconds, a silent connection
+// is considered dead after readTimeout and is then reopened after a delay
+// doubled on each failure
+const (
+	readTimeout      = 10 * time.Second
+	handshakeTimeout = 10 * time.Second
+	minBackoff       = 1 * time.Second
+	maxBackoff       = 30 * time.Second
+)
+
+// ErrRejected is returned when the server rejects the subscription
+var ErrRejected = errors.New("subscription rejected")
+
+// HandshakeError is returned when the server refuses the websocket
+type HandshakeError struct {
+	StatusCode int
+}
+
+func (e *HandshakeError) Error() string {
+	return fmt.Sprintf("Unable to open the event stream: %s", http.StatusText(e.StatusCode))
+}
+
+// DisconnectError is returned when the server closes the connection and
+// asks not to reconnect
+type DisconnectError struct {
+	Reason string
+}
+
+func (e *DisconnectError) Error() string {
+	return fmt.Sprintf("disconnected by the server: %s", e.Reason)
+}
+
+// Subscription describes a channel to subscribe to
+type Subscription struct {
+	// URL of the ActionCable endpoint, such as wss://host/cable
+	URL string
+	// Header is sent along with the websocket handshake
+	Header http.Header
+	// Identifier is the JSON identifier of the channel, as sent to the server
+	Identifier string
+}
+
+// message is a message sent by the server, Type is empty for the messages
+// of the channels
+type message struct {
+	Type       string          `json:"type"`
+	Identifier string          `json:"identifier"`
+	Message    json.RawMessage `json:"message"`
+	Reason     string          `json:"reason"`
+	Reconnect  *bool           `json:"reconnect"`
+}
+
+// command is a message sent to the server
+type command struct {
+	Command    string `json:"command"`
+	Identifier string `json:"identifier"`
+}
+
+// Run subscribes to the channel and calls receive with each of its messages,
+// from a single goroutine, until ctx is done. The connection is reopened
+// when lost. It returns nil once ctx is done, and the error which ended the
+// subscription otherwise: a rejection, a refused handshake or a disconnection
+// asked by the server.
+func Run(ctx context.Context, sub Subscription, receive func(json.RawMessage)) error {
+	delay := minBackoff
+	for {
+		confirmed, err := listen(ctx, sub, receive)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !transient(err) {
+			return err
+		}
+		if confirmed {
+			delay = minBackoff
+		}
+
+		logger.Debug.Printf("ActionCable connection lost, retrying in %s: %s", delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		if delay *= 2; delay > maxBackoff {
+			delay = maxBackoff
+		}
+	}
+}
+
+// listen subscribes on a new connection and receives its messages until the
+// connection fails, it tells whether the subscription was confirmed
+func listen(ctx context.Context, sub Subscription, receive func(json.RawMessage)) (bool, error) {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: handshakeTimeout,
+	}
+	conn, res, err := dialer.DialContext(ctx, sub.URL, sub.Header)
+	if errors.Is(err, websocket.ErrBadHandshake) && res != nil {
+		return false, &HandshakeError{StatusCode: res.StatusCode}
+	} else if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	confirmed := false
+	for {
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+
+		var msg message
+		if err := conn.ReadJSON(&msg); err != nil {
+			return confirmed, err
+		}
+
+		switch msg.Type {
+		case "welcome":
+			if err := conn.WriteJSON(command{Command: "subscribe", Identifier: sub.Identifier}); err != nil {
+				return confirmed, err
+			}
+		case "ping":
+		case "confirm_subscription":
+			confirmed = true
+		case "reject_subscription":
+			return confirmed, ErrRejected
+		case "disconnect":
+			if msg.Reconnect != nil && !*msg.Reconnect {
+				return confirmed, &DisconnectError{Reason: msg.Reason}
+			}
+			return confirmed, fmt.Errorf("disconnected by the server: %s", msg.Reason)
+		case "":
+			if msg.Identifier == sub.Identifier && len(msg.Message) > 0 {
+				receive(msg.Message)
+			}
+		default:
+			logger.Debug.Printf("ActionCable %q message", msg.Type)
+		}
+	}
+}
+
+// transient tells whether the subscription is retried after err
+func transient(err error) bool {
+	var handshakeErr *HandshakeError
+	var disconnectErr *DisconnectError
+	switch {
+	case errors.Is(err, ErrRejected), errors.As(err, &disconnectErr):
+		return false
+	case errors.As(err, &handshakeErr):
+		switch handshakeErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
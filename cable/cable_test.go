@@ -0,0 +1,95 @@
+package cable_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/squarescale/squarescale-cli/cable"
+)
+
+const identifier = `{"channel":"ProjectChannel","project":"project-uuid"}`
+
+// server greets the client, confirms its subscription and sends a message
+// before closing the connection, counting the connections
+func server(t *testing.T, connections *int32) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Websocket upgrade failed: %s", err)
+			return
+		}
+		defer conn.Close()
+		n := atomic.AddInt32(connections, 1)
+
+		conn.WriteJSON(map[string]string{"type": "welcome"})
+		var command map[string]string
+		if err := conn.ReadJSON(&command); err != nil {
+			return
+		}
+		if command["command"] != "subscribe" || command["identifier"] != identifier {
+			t.Errorf("Unexpected command `%v`", command)
+		}
+		conn.WriteJSON(map[string]string{"type": "confirm_subscription", "identifier": identifier})
+		conn.WriteJSON(map[string]interface{}{"identifier": identifier, "message": map[string]int32{"connection": n}})
+	}))
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Test reconnection", testReconnection)
+	t.Run("Test refused handshake", testRefusedHandshake)
+}
+
+func testReconnection(t *testing.T) {
+	// given
+	var connections int32
+	s := server(t, &connections)
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sub := cable.Subscription{URL: strings.Replace(s.URL, "http", "ws", 1), Identifier: identifier}
+
+	// when
+	var received []string
+	err := cable.Run(ctx, sub, func(message json.RawMessage) {
+		received = append(received, string(message))
+		if len(received) == 2 {
+			cancel()
+		}
+	})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+	expected := `{"connection":1}, {"connection":2}`
+	if strings.Join(received, ", ") != expected {
+		t.Errorf("Expect messages `%s`, got `%s`", expected, strings.Join(received, ", "))
+	}
+}
+
+func testRefusedHandshake(t *testing.T) {
+	// given
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+	sub := cable.Subscription{URL: strings.Replace(s.URL, "http", "ws", 1), Identifier: identifier}
+
+	// when
+	err := cable.Run(context.Background(), sub, func(json.RawMessage) {})
+
+	// then
+	var handshakeErr *cable.HandshakeError
+	if !errors.As(err, &handshakeErr) || handshakeErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expect a handshake error, got `%v`", err)
+	}
+}
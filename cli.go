@@ -4,11 +4,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mitchellh/cli"
 	"github.com/squarescale/squarescale-cli/command"
+	"github.com/squarescale/squarescale-cli/config"
+	"github.com/squarescale/squarescale-cli/ui"
 )
 
 func defValueFromEnv(envname string, def bool) bool {
@@ -30,14 +33,66 @@ func defDurationFromEnv(envname string, def time.Duration) time.Duration {
 	}
 }
 
+func defIntFromEnv(envname string, def int) int {
+	env, err := strconv.Atoi(os.Getenv(envname))
+	if err == nil {
+		return env
+	} else {
+		return def
+	}
+}
+
+func defStringFromEnv(envname string, def string) string {
+	env := os.Getenv(envname)
+	if env != "" {
+		return env
+	} else {
+		return def
+	}
+}
+
+func defBool(value *bool, def bool) bool {
+	if value != nil {
+		return *value
+	}
+	return def
+}
+
+func defString(value string, def string) string {
+	if value != "" {
+		return value
+	}
+	return def
+}
+
 func Run(args []string) int {
 	var f flag.FlagSet
 
-	color := f.Bool("color", defValueFromEnv("SQSC_COLOR", command.IsTTY), "Colored output")
-	format := f.Bool("format", defValueFromEnv("SQSC_FORMAT", true), "Enable nice output")
-	spin := f.Bool("progress", defValueFromEnv("SQSC_PROGRESS", command.IsTTY), "Enable progress spinner")
+	// Defaults of the configuration file, overridden by the ones of the
+	// per-directory file, themselves overridden by environment and flags
+	c, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	local, err := config.LoadLocal()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	defaults := c.Defaults
+	defaults.Merge(local)
+
+	color := f.Bool("color", defValueFromEnv("SQSC_COLOR", defBool(defaults.Color, command.IsTTY)), "Colored output")
+	format := f.Bool("format", defValueFromEnv("SQSC_FORMAT", defBool(defaults.Format, true)), "Enable nice output")
+	spin := f.Bool("progress", defValueFromEnv("SQSC_PROGRESS", defBool(defaults.Progress, command.IsTTY)), "Enable progress spinner")
+	output := f.String("output", defStringFromEnv("SQSC_OUTPUT", defString(defaults.Output, ui.OutputTable)), "Output format of read commands: "+strings.Join(ui.OutputFormats, ", "))
+	retryMax := f.Int("retry-max", defIntFromEnv("SQSC_RETRY_MAX", 0), "Number of retries of API calls on transient failures")
+	context := f.String("context", defStringFromEnv("SQSC_CONTEXT", ""), "Context to use, instead of the current one")
+	retryNonIdempotent := f.Bool("retry-non-idempotent", defValueFromEnv("SQSC_RETRY_NON_IDEMPOTENT", false), "Also retry API calls creating or updating resources")
+	waitEvents := f.Bool("wait-events", defValueFromEnv("SQSC_WAIT_EVENTS", false), "Also check the awaited resources as soon as a project event is received, on top of the regular polling")
 
-	err := f.Parse(args)
+	err = f.Parse(args)
 	if err == flag.ErrHelp {
 		return 0
 	} else if err != nil {
@@ -52,6 +107,16 @@ func Run(args []string) int {
 		ErrorWriter: os.Stderr,
 		Reader:      os.Stdin,
 	}, *color, *format, *spin, defDurationFromEnv("SQSC_SPIN_TIME", 0))
+	meta.SetRetry(*retryMax, *retryNonIdempotent)
+	meta.SetWaitEvents(*waitEvents)
+	if err := meta.SetOutput(*output); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	if err := meta.SetConfig(c, local, *context); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
 
 	return RunCustom(f.Args(), Commands(meta))
 }
@@ -0,0 +1,190 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/squarescale/squarescale-cli/manifest"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// ApplyCommand is a cli.Command implementation for bringing a project to the
+// state described by a manifest file.
+type ApplyCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ApplyCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	file := manifestFileFlag(cmd.flagSet)
+	alwaysYes := yesFlag(cmd.flagSet)
+	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *file == "" {
+		return cmd.errorWithUsage(errors.New("Manifest file is mandatory"))
+	}
+
+	m, err := manifest.Load(*file)
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	return cmd.applyManifest(endpoint.String(), m, alwaysYes, *nowait, *waitTimeout)
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ApplyCommand) Synopsis() string {
+	return "Create or update a project from a manifest file"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ApplyCommand) Help() string {
+	helpText := `
+usage: sqsc apply -f project.yaml [options]
+
+  Creates or updates the project described by the manifest file so that it
+  matches it: the project itself, its database, redis, volumes, scheduling
+  groups, services with their network rules, batches and environment.
+
+  A new project is provisioned before its other resources are created, which
+  is waited for even with -nowait.
+
+  Applying the same manifest twice does nothing the second time. Resources
+  which are not in the manifest are left untouched. Nothing is applied when
+  resources differ on settings which can not be changed once created
+  (provider, region, node size, images, volumes): they are marked with -/+
+  and have to be replaced by hand.
+
+  The username and password of private Docker images can be written as
+  references to the local environment, ${env:NAME}, resolved before the
+  changes are computed.
+
+  Example of manifest:
+
+    project:
+      name: my-project
+      provider: aws
+      region: eu-west-1
+      credential: my-credential
+      node-size: t3.medium
+    database:
+      engine: postgres
+      size: small
+    services:
+      - name: web
+        image: nginx
+        instances: 2
+        network-rules:
+          - name: http
+            internal-port: 80
+    env:
+      LOG_LEVEL: info
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
+
+// applyManifest shows the changes bringing the project to its manifest, asks
+// for confirmation, then applies them and waits for the project unless nowait
+// is set.
+func (meta *Meta) applyManifest(endpoint string, m *manifest.Manifest, alwaysYes *bool, nowait bool, waitTimeout time.Duration) int {
+	if err := m.ResolveImageCredentials(os.LookupEnv); err != nil {
+		return meta.error(err)
+	}
+
+	var plan *manifest.Plan
+	res := meta.runWithSpinner("compute changes", endpoint, func(client *squarescale.Client) (string, error) {
+		var err error
+		plan, err = manifest.NewPlan(client, m)
+		return "", err
+	})
+	if res != 0 {
+		return res
+	}
+
+	pending := plan.Pending()
+	if plan.HasChanges() {
+		meta.Ui.Output(plan.String())
+	}
+	if err := plan.Applicable(); err != nil {
+		return meta.error(err)
+	}
+	if len(pending) != len(plan.Changes) {
+		meta.Ui.Warn("Resources marked with - are not in the manifest and are left untouched")
+	}
+	if len(pending) == 0 {
+		meta.Ui.Info(fmt.Sprintf("Project '%s' is up to date", m.Project.Name))
+		return 0
+	}
+
+	ok, err := AskYesNo(meta.Ui, alwaysYes, "Apply these changes?", false)
+	if err != nil {
+		return meta.error(err)
+	} else if !ok {
+		return meta.cancelled()
+	}
+
+	// a new project is created and provisioned first, as in project clone,
+	// its other resources being created once it is ready
+	if plan.ProjectUUID == "" && len(pending) > 1 {
+		res = meta.runWithSpinner("create project", endpoint, func(client *squarescale.Client) (string, error) {
+			projectPlan, err := manifest.NewPlan(client, m.ProjectOnly())
+			if err != nil {
+				return "", err
+			}
+			if _, err := projectPlan.Apply(client); err != nil {
+				return "", err
+			}
+			plan.ProjectUUID = projectPlan.ProjectUUID
+			return "", nil
+		})
+		if res != 0 {
+			return res
+		}
+
+		res = meta.runWithSpinner("wait for project", endpoint, func(client *squarescale.Client) (string, error) {
+			_, err := client.WaitProjectWithOptions(plan.ProjectUUID, meta.waitOptions("wait for project", waitTimeout))
+			return "", err
+		})
+		if res != 0 {
+			return res
+		}
+
+		res = meta.runWithSpinner("compute changes", endpoint, func(client *squarescale.Client) (string, error) {
+			var err error
+			plan, err = manifest.NewPlan(client, m)
+			return "", err
+		})
+		if res != 0 {
+			return res
+		}
+	}
+
+	res = meta.runWithSpinner("apply changes", endpoint, func(client *squarescale.Client) (string, error) {
+		applied, err := plan.Apply(client)
+		return fmt.Sprintf("Applied %d change(s) to project '%s'", applied, m.Project.Name), err
+	})
+	if res != 0 || nowait {
+		return res
+	}
+
+	return meta.runWithSpinner("wait for project", endpoint, func(client *squarescale.Client) (string, error) {
+		_, err := client.WaitProjectWithOptions(plan.ProjectUUID, meta.waitOptions("wait for project", waitTimeout))
+		return "", err
+	})
+}
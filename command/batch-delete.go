@@ -20,10 +20,10 @@ func (cmd *BatchDeleteCommand) Run(args []string) int {
 	// Parse flags
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
 	alwaysYes := yesFlag(cmd.flagSet)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	batchName := batchNameFlag(cmd.flagSet)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -18,10 +18,10 @@ type BatchExecuteCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *BatchExecuteCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	batchName := batchNameFlag(cmd.flagSet)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
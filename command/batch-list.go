@@ -18,9 +18,10 @@ type BatchListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *BatchListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	reveal := revealFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -51,6 +52,13 @@ func (cmd *BatchListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			if !*reveal {
+				batches = redactBatches(batches)
+			}
+			return cmd.formatRaw(batches)
+		}
+
 		var msg string = "Name\t\tDocker image\tPeriodic\n"
 		for _, b := range batches {
 			msg += fmt.Sprintf("%s\t%s\t%t\n", b.BatchCommon.Name, b.DockerImage.Name, b.BatchCommon.Periodic)
@@ -77,6 +85,9 @@ func (cmd *BatchListCommand) Help() string {
 usage: sqsc batch list [options]
 
   List batches of project.
+
 `
+	helpText += redactionHelp("With a raw output format, the values of the batch variables", "reveal", true) + "\n"
+
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
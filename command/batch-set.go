@@ -18,9 +18,9 @@ type BatchSetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *BatchSetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	batchName := batchNameFlag(cmd.flagSet)
 	runCmdArg := containerRunCmdFlag(cmd.flagSet)
 	entrypoint := entrypointFlag(cmd.flagSet)
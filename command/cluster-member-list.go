@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/ui"
 )
 
 // ClusterMemberListCommand is a cli.Command implementation for listing external nodes.
@@ -18,14 +19,22 @@ type ClusterMemberListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ClusterMemberListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	output := listOutputFlag(cmd.flagSet, cmd.output)
+	columns := columnsFlag(cmd.flagSet)
+	sortBy := sortByFlag(cmd.flagSet)
+	noHeaders := noHeadersFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
 
+	if err := cmd.SetOutput(*output); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
 	if cmd.flagSet.NArg() > 0 {
 		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
 	}
@@ -51,20 +60,20 @@ func (cmd *ClusterMemberListCommand) Run(args []string) int {
 			return "", err
 		}
 
-		var msg string = "Name\t\t\t\tPublicIP\tPrivateIP\tStatus\tScheduling group\n"
+		if cmd.rawOutput() {
+			return cmd.formatRaw(clusterMembers)
+		}
+
+		table := ui.NewTable("Name", "PublicIP", "PrivateIP", "Status", "Scheduling group")
 		for _, cm := range clusterMembers {
-			if cm.PublicIP == "" {
-				msg += fmt.Sprintf("%s\t\t\t\t%s\t%s\t%s\n", cm.Name, cm.PrivateIP, cm.Status, cm.SchedulingGroup.Name)
-			} else {
-				msg += fmt.Sprintf("%s\t%s\t%s\t%s\t%s\n", cm.Name, cm.PublicIP, cm.PrivateIP, cm.Status, cm.SchedulingGroup.Name)
-			}
+			table.Append(cm.Name, cm.PublicIP, cm.PrivateIP, cm.Status, cm.SchedulingGroup.Name)
 		}
 
 		if len(clusterMembers) == 0 {
-			msg = "No cluster members"
+			return "No cluster members", nil
 		}
 
-		return msg, nil
+		return cmd.renderTable(table, *columns, *sortBy, *noHeaders)
 	})
 }
 
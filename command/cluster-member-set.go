@@ -17,9 +17,9 @@ type ClusterMemberSetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ClusterMemberSetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	nameArg := clusterNameFlag(cmd.flagSet)
 	schedulingGroupArg := clusterSchedulingGroupsFlag(cmd.flagSet)
 
@@ -20,10 +20,11 @@ type ClusterSetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ClusterSetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	cmd.Cluster.Size = *clusterSizeFlag(cmd.flagSet)
 	alwaysYes := yesFlag(cmd.flagSet)
 	if err := cmd.flagSet.Parse(args); err != nil {
@@ -91,8 +92,9 @@ func (cmd *ClusterSetCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
+		opts := cmd.waitOptions("wait for cluster change", *waitTimeout)
 		res = cmd.runWithSpinner("wait for cluster change", endpoint.String(), func(client *squarescale.Client) (string, error) {
-			projectStatus, err := client.WaitProject(UUID, 5)
+			projectStatus, err := client.WaitProjectWithOptions(UUID, opts)
 			if err != nil {
 				return projectStatus, err
 			} else {
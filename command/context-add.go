@@ -0,0 +1,90 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/config"
+)
+
+// ContextAddCommand adds or updates a context in the configuration file.
+type ContextAddCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ContextAddCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := cmd.flagSet.String("endpoint", "", "SquareScale endpoint of the context")
+	token := cmd.flagSet.String("token-ref", "", "Name of the token in the token store, the endpoint by default")
+	organization := cmd.flagSet.String("organization", "", "Default organization of the context")
+	project := cmd.flagSet.String("project", "", "Default project of the context")
+	use := cmd.flagSet.Bool("use", false, "Make it the current context")
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() != 1 {
+		return cmd.errorWithUsage(errors.New("Context name is mandatory"))
+	}
+	name := cmd.flagSet.Arg(0)
+
+	if *endpoint == "" {
+		return cmd.errorWithUsage(errors.New("Endpoint is mandatory"))
+	}
+
+	c, err := config.Load()
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	err = c.SetContext(config.Context{
+		Name:         name,
+		Endpoint:     strings.TrimSuffix(*endpoint, "/"),
+		Token:        *token,
+		Organization: *organization,
+		Project:      *project,
+	})
+	if err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
+	if *use || len(c.Contexts) == 1 {
+		c.CurrentContext = name
+	}
+
+	if err := c.Save(); err != nil {
+		return cmd.error(err)
+	}
+
+	return cmd.info("Successfully saved context '%s'", name)
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ContextAddCommand) Synopsis() string {
+	return "Add or update a context"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ContextAddCommand) Help() string {
+	helpText := `
+usage: sqsc context add [options] <name>
+
+  Add a context, or update the one of the same name. The first context added
+  becomes the current one.
+
+  The token of the context is looked up in the token store under the name
+  given by -token-ref, so that several accounts can be used on the same
+  endpoint, and is saved there by sqsc login. The default project is used
+  when -project-name is not given.
+
+  Example:
+
+    sqsc context add -endpoint https://www.squarescale.io -organization acme -project web acme-web
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/config"
+)
+
+// ContextDeleteCommand deletes a context from the configuration file.
+type ContextDeleteCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ContextDeleteCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() != 1 {
+		return cmd.errorWithUsage(errors.New("Context name is mandatory"))
+	}
+	name := cmd.flagSet.Arg(0)
+
+	c, err := config.Load()
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	if err := c.DeleteContext(name); err != nil {
+		return cmd.error(err)
+	}
+
+	if err := c.Save(); err != nil {
+		return cmd.error(err)
+	}
+
+	return cmd.info("Successfully deleted context '%s'", name)
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ContextDeleteCommand) Synopsis() string {
+	return "Delete a context"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ContextDeleteCommand) Help() string {
+	helpText := `
+usage: sqsc context delete <name>
+
+  Delete a context. Its token is left in the token store, see sqsc logout.
+  There is no current context anymore when the current one is deleted.
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
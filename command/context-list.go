@@ -0,0 +1,81 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/config"
+	"github.com/squarescale/squarescale-cli/ui"
+)
+
+// ContextListCommand lists the contexts of the configuration file.
+type ContextListCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ContextListCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	output := listOutputFlag(cmd.flagSet, cmd.output)
+	columns := columnsFlag(cmd.flagSet)
+	sortBy := sortByFlag(cmd.flagSet)
+	noHeaders := noHeadersFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := cmd.SetOutput(*output); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	c, err := config.Load()
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	var msg string
+	if cmd.rawOutput() {
+		msg, err = cmd.formatRaw(c.Contexts)
+	} else if len(c.Contexts) == 0 {
+		msg = "No context found"
+	} else {
+		table := ui.NewTable("Current", "Name", "Endpoint", "Token", "Organization", "Project")
+		for _, context := range c.Contexts {
+			var current string
+			if context.Name == c.CurrentContext {
+				current = "*"
+			}
+			table.Append(current, context.Name, context.Endpoint, context.TokenKey(), context.Organization, context.Project)
+		}
+		msg, err = cmd.renderTable(table, *columns, *sortBy, *noHeaders)
+	}
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	cmd.Ui.Output(msg)
+	return 0
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ContextListCommand) Synopsis() string {
+	return "List contexts"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ContextListCommand) Help() string {
+	helpText := `
+usage: sqsc context list [options]
+
+  List the contexts, the current one being marked with a star.
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
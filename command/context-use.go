@@ -0,0 +1,61 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/config"
+)
+
+// ContextUseCommand makes a context the current one.
+type ContextUseCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ContextUseCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() != 1 {
+		return cmd.errorWithUsage(errors.New("Context name is mandatory"))
+	}
+	name := cmd.flagSet.Arg(0)
+
+	c, err := config.Load()
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	if err := c.UseContext(name); err != nil {
+		return cmd.error(err)
+	}
+
+	if err := c.Save(); err != nil {
+		return cmd.error(err)
+	}
+
+	return cmd.info("Switched to context '%s'", name)
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ContextUseCommand) Synopsis() string {
+	return "Make a context the current one"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ContextUseCommand) Help() string {
+	helpText := `
+usage: sqsc context use <name>
+
+  Make a context the current one, used by the commands unless the global
+  -context option selects another one.
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// ContextCommand is a cli.Command implementation for top level `sqsc context` command.
+type ContextCommand struct {
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ContextCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ContextCommand) Synopsis() string {
+	return "Commands to manage the contexts: endpoints, accounts and defaults"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ContextCommand) Help() string {
+	helpText := `
+usage: sqsc context <subcommand>
+
+  Run a context related command. A context names an endpoint along with the
+  token and the default organization and project used with it. Contexts are
+  stored in $XDG_CONFIG_HOME/sqsc/config.yaml, ~/.config/sqsc/config.yaml by
+  default, or in the file given by SQSC_CONFIG.
+
+  The current context is used unless the global -context option, or
+  SQSC_CONTEXT, selects another one.
+
+  List of supported subcommands is available below.
+
+`
+	return strings.TrimSpace(helpText)
+}
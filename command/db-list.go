@@ -21,7 +21,7 @@ type DBListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *DBListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	provider := providerFlag(cmd.flagSet)
 	region := regionFlag(cmd.flagSet)
 
@@ -52,6 +52,13 @@ func (cmd *DBListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(struct {
+				Engines []squarescale.DataseEngine `json:"engines"`
+				Sizes   []squarescale.DataseSize   `json:"sizes"`
+			}{engines, sizes})
+		}
+
 		var out string = "\n\tAvailable engines\n\n"
 		out += fmtDbEngineListOutput(engines)
 		out += "\n\n\tAvailable sizes\n\n"
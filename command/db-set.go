@@ -18,10 +18,11 @@ type DBSetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *DBSetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	dbEngine := dbEngineFlag(cmd.flagSet)
 	dbSize := dbSizeFlag(cmd.flagSet)
 	dbVersion := dbVersionFlag(cmd.flagSet)
@@ -115,8 +116,9 @@ func (cmd *DBSetCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
+		opts := cmd.waitOptions("wait for database change", *waitTimeout)
 		res = cmd.runWithSpinner("wait for database change", endpoint.String(), func(client *squarescale.Client) (string, error) {
-			projectStatus, err := client.WaitProject(UUID, 5)
+			projectStatus, err := client.WaitProjectWithOptions(UUID, opts)
 			if err != nil {
 				return projectStatus, err
 			} else {
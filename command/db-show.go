@@ -18,9 +18,9 @@ type DBShowCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *DBShowCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
@@ -50,6 +50,10 @@ func (cmd *DBShowCommand) Run(args []string) int {
 			return "", e
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(db)
+		}
+
 		return cmd.FormatTable(
 			fmt.Sprintf(
 				"DB Enabled:\t%v\nDB Engine:\t%s\nDB Size:\t%s\nDB Version:\t%s\nDB Backup Enabled:\t%v\nDB Backup Retention:\t%v",
@@ -0,0 +1,155 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/redact"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// maskedValue replaces the values of the variables unless they are shown
+const maskedValue = "<masked>"
+
+// EnvDiffCommand compares the environment variables of two projects or
+// services.
+type EnvDiffCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *EnvDiffCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	from := cmd.flagSet.String("from", "", "Project, or project:service, to compare from")
+	to := cmd.flagSet.String("to", "", "Project, or project:service, to compare to")
+	showValues := cmd.flagSet.Bool("show-values", false, "Show the values of the variables instead of masking them")
+	reveal := revealFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *from == "" || *to == "" {
+		return cmd.errorWithUsage(errors.New("Both -from and -to are mandatory"))
+	}
+
+	return cmd.runWithSpinner("compare environment variables", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		fromGroup, err := envGroup(client, *from)
+		if err != nil {
+			return "", err
+		}
+
+		toGroup, err := envGroup(client, *to)
+		if err != nil {
+			return "", err
+		}
+
+		diffs := squarescale.DiffVariables(fromGroup, toGroup)
+		for i, diff := range diffs {
+			if !*showValues {
+				diffs[i].From, diffs[i].To = maskVariable(diff.From), maskVariable(diff.To)
+			} else if !*reveal {
+				diffs[i].From, diffs[i].To = redactVariable(diff.From), redactVariable(diff.To)
+			}
+		}
+
+		if cmd.rawOutput() {
+			return cmd.formatRaw(diffs)
+		}
+
+		if len(diffs) == 0 {
+			return fmt.Sprintf("No difference between '%s' and '%s'", *from, *to), nil
+		}
+
+		lines := make([]string, 0, len(diffs))
+		for _, diff := range diffs {
+			lines = append(lines, diff.String(*showValues))
+		}
+		return strings.Join(lines, "\n"), nil
+	})
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *EnvDiffCommand) Synopsis() string {
+	return "Compare environment variables of projects or services"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *EnvDiffCommand) Help() string {
+	helpText := `
+usage: sqsc env diff -from project[:service] -to project[:service] [options]
+
+  Compare the environment variables of two projects, or of services of
+  projects, service variables including the project wide ones. Variables
+  are listed with a + when added, a - when removed and a ~ when changed,
+  along with their kind (custom or predefined).
+
+  Values are masked unless -show-values is used, secret values being
+  redacted, as by sqsc env get, unless -reveal is used too.
+
+  Example:
+
+    sqsc env diff -from staging:web -to production:web
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
+
+// envGroup returns the variables of a project, or of one of its services
+// when target is of the form project:service
+func envGroup(client *squarescale.Client, target string) (*squarescale.VariableGroup, error) {
+	projectName, serviceName := target, ""
+	if i := strings.LastIndex(target, ":"); i >= 0 {
+		projectName, serviceName = target[:i], target[i+1:]
+	}
+
+	_, _, group, err := projectEnv(client, projectName, serviceName)
+	return group, err
+}
+
+// projectEnv returns the UUID and the environment of a project, along with
+// the variables of the project or of the given service. Values are returned
+// as is, whatever their redaction.
+func projectEnv(client *squarescale.Client, projectName, serviceName string) (string, *squarescale.Environment, *squarescale.VariableGroup, error) {
+	UUID, err := client.ProjectByName(projectName)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	env, err := squarescale.NewEnvironment(client, UUID)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	result, err := env.QueryVars(squarescale.QueryOptions{ServiceName: serviceName, Reveal: true})
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return UUID, env, result.(*squarescale.VariableGroup), nil
+}
+
+func redactVariable(variable *squarescale.Variable) *squarescale.Variable {
+	if variable == nil {
+		return nil
+	}
+	redacted := *variable
+	redacted.Value = redact.Value(variable.Key, variable.Value)
+	return &redacted
+}
+
+func maskVariable(variable *squarescale.Variable) *squarescale.Variable {
+	if variable == nil {
+		return nil
+	}
+	masked := *variable
+	masked.Value = maskedValue
+	return &masked
+}
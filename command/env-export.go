@@ -0,0 +1,128 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/redact"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// EnvExportCommand prints the environment variables of a project in a file
+// format.
+type EnvExportCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *EnvExportCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	projectUUID := projectUUIDFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	container := serviceFlag(cmd.flagSet)
+	format := envFormatFlag(cmd.flagSet, cmd.output)
+	all := cmd.flagSet.Bool("all", false, "Export predefined variables too")
+	reveal := revealFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *projectUUID == "" && *projectName == "" {
+		return cmd.errorWithUsage(errors.New("Project name or uuid is mandatory"))
+	}
+
+	if _, err := squarescale.FormatVariables(nil, *format); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+	// disables the spinner so that it does not mix with the variables
+	cmd.spinEnable = false
+
+	return cmd.runWithSpinner("export environment variables", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		var UUID string
+		var err error
+		if *projectUUID == "" {
+			UUID, err = client.ProjectByName(*projectName)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			UUID = *projectUUID
+		}
+
+		env, err := squarescale.NewEnvironment(client, UUID)
+		if err != nil {
+			return "", err
+		}
+
+		group := env.Project
+		if *container != "" {
+			group, err = env.GetServiceGroup(*container)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		variables := group.CustomVariables()
+		if *all {
+			for _, variable := range group.Variables {
+				if _, ok := variables[variable.Key]; !ok {
+					variables[variable.Key] = variable.Value
+				}
+			}
+		}
+
+		if !*reveal {
+			variables = redact.Variables(variables)
+		}
+
+		// env import resolves the ${VAR} of the dotenv values only
+		if squarescale.InterpolatedFormat(*format) {
+			variables = squarescale.EscapeReferences(variables)
+		}
+
+		return squarescale.FormatVariables(variables, *format)
+	})
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *EnvExportCommand) Synopsis() string {
+	return "Export environment variables of project"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *EnvExportCommand) Help() string {
+	helpText := `
+usage: sqsc env export [options]
+
+  Print the custom environment variables of the project, or of a service
+  along with the project wide ones, sorted by name in one of the formats:
+
+    dotenv  KEY=value lines, values being quoted when needed (default)
+    shell   export KEY='value' lines, to be evaluated by a POSIX shell
+    json    an object of the variables
+    yaml    a mapping of the variables
+
+  Secret values are redacted, as by sqsc env get, unless -reveal is used.
+  Redacted values are left unchanged by sqsc env import.
+
+  In the dotenv format, the ${ of the values are written $${, so that
+  sqsc env import sets them as is instead of resolving them as references.
+  The values of the other formats are written as is.
+
+  Example:
+
+    sqsc env export -project-name staging -reveal > staging.env
+    eval "$(sqsc env export -project-name staging -format shell -reveal)"
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
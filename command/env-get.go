@@ -18,11 +18,12 @@ type EnvGetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *EnvGetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	container := serviceFlag(cmd.flagSet)
 	all := envAllFlag(cmd.flagSet)
+	reveal := revealFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -59,12 +60,17 @@ func (cmd *EnvGetCommand) Run(args []string) int {
 			DisplayAll:   *all,
 			ServiceName:  *container,
 			VariableName: queryVar,
+			Reveal:       *reveal,
 		}
 		queryResult, err := env.QueryVars(queryOptions)
 		if err != nil {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(queryResult)
+		}
+
 		return queryResult.String(), nil
 	})
 }
@@ -80,6 +86,9 @@ func (cmd *EnvGetCommand) Help() string {
 usage: sqsc env get [options] [VARNAME]
 
   Display environment variables of project.
+
 `
+	helpText += redactionHelp("The values of the variables", "reveal", true) + "\n"
+
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
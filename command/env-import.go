@@ -0,0 +1,266 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/redact"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// EnvImportCommand sets the environment variables of a project from a file.
+type EnvImportCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *EnvImportCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	projectUUID := projectUUIDFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	container := serviceFlag(cmd.flagSet)
+	file := cmd.flagSet.String("f", "", "File containing the variables, - for the standard input")
+	format := cmd.flagSet.String("format", "", "Format of the file: dotenv, json, yaml or shell, guessed from its extension by default")
+	replace := cmd.flagSet.Bool("replace", false, "Remove the custom variables which are not in the file")
+	merge := cmd.flagSet.Bool("merge", false, "Keep the custom variables which are not in the file (default)")
+	dryRun := cmd.flagSet.Bool("dry-run", false, "Print the changes without applying them")
+	noInterpolate := noInterpolateFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *projectUUID == "" && *projectName == "" {
+		return cmd.errorWithUsage(errors.New("Project name or uuid is mandatory"))
+	}
+
+	if *file == "" {
+		return cmd.errorWithUsage(errors.New("File of variables is mandatory"))
+	}
+
+	if *replace && *merge {
+		return cmd.errorWithUsage(errors.New("Cannot use -replace and -merge together"))
+	}
+
+	if *format == "" {
+		*format = envFileFormat(*file)
+	}
+
+	variables, err := readVariables(*file, *format)
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	return cmd.runWithSpinner("import environment variables", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		var UUID string
+		var err error
+		if *projectUUID == "" {
+			UUID, err = client.ProjectByName(*projectName)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			UUID = *projectUUID
+		}
+
+		env, err := squarescale.NewEnvironment(client, UUID)
+		if err != nil {
+			return "", err
+		}
+
+		group := env.Project
+		target := "project"
+		if *container != "" {
+			group, err = env.GetServiceGroup(*container)
+			if err != nil {
+				return "", err
+			}
+			target = fmt.Sprintf("container '%s'", *container)
+		}
+
+		imported, err := restoreRedactedURLs(variables, group)
+		if err != nil {
+			return "", err
+		}
+		if !*noInterpolate && squarescale.InterpolatedFormat(*format) {
+			imported, err = interpolateVariables(imported, group)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		changes, err := importVariables(group, imported, *replace)
+		if err != nil {
+			return "", err
+		}
+
+		if len(changes) == 0 {
+			return fmt.Sprintf("Environment of %s is up to date", target), nil
+		}
+
+		if *dryRun {
+			return fmt.Sprintf("%s\nDry run: the environment of %s was left unchanged", strings.Join(changes, "\n"), target), nil
+		}
+
+		msg := fmt.Sprintf("%s\nSuccessfully imported %d change(s) for %s", strings.Join(changes, "\n"), len(changes), target)
+		return msg, env.CommitEnvironment(client, UUID)
+	})
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *EnvImportCommand) Synopsis() string {
+	return "Import environment variables of project from a file"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *EnvImportCommand) Help() string {
+	helpText := `
+usage: sqsc env import -f file [options]
+
+  Set the environment variables of the project, or of a service, from a file
+  in one of the formats of sqsc env export: files ending with .json, .yaml or
+  .yml are read as JSON or YAML, the others as dotenv or shell files.
+
+  All the variables are set at once. By default the custom variables which
+  are not in the file are kept, -replace removes them. Variables whose value
+  was redacted by sqsc env export are left unchanged, and the redacted URL
+  passwords are taken from the current value of the variable. The names of
+  the changed variables are printed, not their values.
+
+  In dotenv files, references to other variables, ${KEY}, and to the local
+  environment, ${env:KEY}, are resolved as by sqsc env set unless
+  -no-interpolate is used, the variables of the file taking precedence over
+  the ones of the project. The values of JSON, YAML and shell files are set
+  as is.
+
+  Example:
+
+    sqsc env import -project-name staging -f staging.env -replace -dry-run
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
+
+// envFileFormat guesses the format of a file of variables from its extension
+func envFileFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return squarescale.EnvFormatJSON
+	case ".yaml", ".yml":
+		return squarescale.EnvFormatYAML
+	default:
+		return squarescale.EnvFormatDotenv
+	}
+}
+
+// readVariables reads the file of variables at path, "-" standing for the
+// standard input
+func readVariables(path, format string) (map[string]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return squarescale.ParseVariables(data, format)
+}
+
+// restoreRedactedURLs puts back the URL passwords redacted by sqsc env export
+// from the current values of the group
+func restoreRedactedURLs(variables map[string]string, group *squarescale.VariableGroup) (map[string]string, error) {
+	current := group.CustomVariables()
+
+	restored := make(map[string]string, len(variables))
+	for key, value := range variables {
+		if value != redact.Redacted && redact.HasRedactedURL(value) {
+			var ok bool
+			value, ok = redact.RestoreURL(value, current[key])
+			if !ok {
+				return nil, fmt.Errorf("Cannot restore the redacted URL password of %s, give its full value", key)
+			}
+		}
+		restored[key] = value
+	}
+	return restored, nil
+}
+
+// interpolateVariables resolves the references of the imported variables,
+// redacted values being left as is for importVariables to skip them
+func interpolateVariables(variables map[string]string, group *squarescale.VariableGroup) (map[string]string, error) {
+	values := map[string]string{}
+	for key, value := range variables {
+		if value != redact.Redacted {
+			values[key] = value
+		}
+	}
+
+	resolved, err := squarescale.Interpolate(values, group.Values(), os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range variables {
+		if _, ok := resolved[key]; !ok {
+			resolved[key] = value
+		}
+	}
+	return resolved, nil
+}
+
+// importVariables sets the variables in the group, removing the other custom
+// variables on replace, and returns the changes made, sorted by name
+func importVariables(group *squarescale.VariableGroup, variables map[string]string, replace bool) ([]string, error) {
+	current := group.CustomVariables()
+
+	keys := make([]string, 0, len(variables)+len(current))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	if replace {
+		for key := range current {
+			if _, ok := variables[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, key := range keys {
+		value, imported := variables[key]
+		previous, exists := current[key]
+		switch {
+		case imported && value == redact.Redacted:
+			continue
+		case !imported:
+			if err := group.RemoveVariable(key); err != nil {
+				return nil, err
+			}
+			changes = append(changes, "- "+key)
+		case !exists:
+			group.SetVariable(key, value)
+			changes = append(changes, "+ "+key)
+		case previous != value:
+			group.SetVariable(key, value)
+			changes = append(changes, "~ "+key)
+		}
+	}
+	return changes, nil
+}
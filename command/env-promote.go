@@ -0,0 +1,162 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// EnvPromoteCommand copies custom environment variables from a project to
+// another one.
+type EnvPromoteCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *EnvPromoteCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	from := cmd.flagSet.String("from", "", "Project to copy the variables from")
+	to := cmd.flagSet.String("to", "", "Project to copy the variables to")
+	keys := cmd.flagSet.String("keys", "", "Variables to copy, separated by commas, all the custom ones by default")
+	container := serviceFlag(cmd.flagSet)
+	dryRun := cmd.flagSet.Bool("dry-run", false, "Print the changes without applying them")
+	reveal := revealFlag(cmd.flagSet)
+	alwaysYes := yesFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *from == "" || *to == "" {
+		return cmd.errorWithUsage(errors.New("Both -from and -to are mandatory"))
+	}
+
+	if *from == *to {
+		return cmd.errorWithUsage(errors.New("Cannot promote variables of a project to itself"))
+	}
+
+	var selected []string
+	for _, key := range strings.Split(*keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			selected = append(selected, key)
+		}
+	}
+
+	var UUID string
+	var env *squarescale.Environment
+	var diffs []squarescale.VariableDiff
+	res := cmd.runWithSpinner("compare environment variables", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		_, _, source, err := projectEnv(client, *from, *container)
+		if err != nil {
+			return "", err
+		}
+
+		var target *squarescale.VariableGroup
+		UUID, env, target, err = projectEnv(client, *to, *container)
+		if err != nil {
+			return "", err
+		}
+
+		diffs, err = promoteVariables(source, target, selected)
+		return "", err
+	})
+	if res != 0 {
+		return res
+	}
+
+	if len(diffs) == 0 {
+		cmd.Ui.Info(fmt.Sprintf("Environment of '%s' is up to date", *to))
+		return 0
+	}
+
+	lines := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		if !*reveal {
+			diff.From, diff.To = redactVariable(diff.From), redactVariable(diff.To)
+		}
+		lines = append(lines, diff.String(true))
+	}
+	cmd.Ui.Output(strings.Join(lines, "\n"))
+
+	if *dryRun {
+		cmd.Ui.Info(fmt.Sprintf("Dry run: the environment of '%s' was left unchanged", *to))
+		return 0
+	}
+
+	ok, err := AskYesNo(cmd.Ui, alwaysYes, fmt.Sprintf("Promote these variables to '%s'?", *to), false)
+	if err != nil {
+		return cmd.error(err)
+	} else if !ok {
+		return cmd.cancelled()
+	}
+
+	return cmd.runWithSpinner("promote environment variables", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		msg := fmt.Sprintf("Successfully promoted %d variable(s) from '%s' to '%s'", len(diffs), *from, *to)
+		return msg, env.CommitEnvironment(client, UUID)
+	})
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *EnvPromoteCommand) Synopsis() string {
+	return "Copy environment variables from a project to another one"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *EnvPromoteCommand) Help() string {
+	helpText := `
+usage: sqsc env promote -from project -to project [options]
+
+  Copy the custom environment variables of a project, or of a service, to
+  another project, or to the service of the same name, after showing the
+  changes and asking for confirmation. Only the given keys are copied when
+  -keys is used. Predefined variables are never copied.
+
+  Secret values are redacted in the changes, as by sqsc env get, unless
+  -reveal is used.
+
+  Example:
+
+    sqsc env promote -from staging -to production -keys LOG_LEVEL,API_URL
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
+
+// promoteVariables sets the custom variables of source, or only the given
+// keys, in target and returns the differences made, sorted by name
+func promoteVariables(source, target *squarescale.VariableGroup, keys []string) ([]squarescale.VariableDiff, error) {
+	custom := source.CustomVariables()
+
+	if len(keys) == 0 {
+		for key := range custom {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+
+	before := &squarescale.VariableGroup{Name: target.Name}
+	for _, variable := range target.Variables {
+		v := *variable
+		before.Variables = append(before.Variables, &v)
+	}
+
+	for _, key := range keys {
+		value, ok := custom[key]
+		if !ok {
+			return nil, fmt.Errorf("Could not find custom variable '%s' to promote", key)
+		}
+		target.SetVariable(key, value)
+	}
+
+	return squarescale.DiffVariables(before, target), nil
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/squarescale/squarescale-cli/redact"
 	"github.com/squarescale/squarescale-cli/squarescale"
 )
 
@@ -18,11 +20,12 @@ type EnvSetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *EnvSetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	container := serviceFlag(cmd.flagSet)
 	remove := envRemoveFlag(cmd.flagSet)
+	noInterpolate := noInterpolateFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -66,6 +69,21 @@ func (cmd *EnvSetCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if !*remove && !*noInterpolate {
+			group := env.Project
+			if *container != "" {
+				if group, err = env.GetServiceGroup(*container); err != nil {
+					return "", err
+				}
+			}
+
+			resolved, err := squarescale.Interpolate(map[string]string{key: value}, group.Values(), os.LookupEnv)
+			if err != nil {
+				return "", err
+			}
+			value = resolved[key]
+		}
+
 		var msg string
 
 		if *container != "" {
@@ -84,7 +102,7 @@ func (cmd *EnvSetCommand) Run(args []string) int {
 				service.SetVariable(key, value)
 				msg = fmt.Sprintf(
 					"Successfully set variable '%s' to value '%s' for container '%s'",
-					key, value, *container)
+					key, redact.Value(key, value), *container)
 			}
 		} else if *remove {
 			if err = env.Project.RemoveVariable(key); err != nil {
@@ -93,7 +111,7 @@ func (cmd *EnvSetCommand) Run(args []string) int {
 			msg = fmt.Sprintf("Successfully removed global variable '%s'", key)
 		} else {
 			env.Project.SetVariable(key, value)
-			msg = fmt.Sprintf("Successfully set global variable '%s' to value '%s'", key, value)
+			msg = fmt.Sprintf("Successfully set global variable '%s' to value '%s'", key, redact.Value(key, value))
 		}
 
 		return msg, env.CommitEnvironment(client, UUID)
@@ -114,6 +132,17 @@ usage: sqsc env set [options] <key> <value>
   must be of the form "<key> <value>" where <key> and <value>
   are both strings. When "--remove" is specified, only the
   key to remove is required.
+
+  The value can reference the other variables of the project,
+  or of the service, as ${KEY}, and the variables of the local
+  environment as ${env:KEY}, $${ standing for a literal ${.
+  References are resolved once, when the variable is set,
+  unless -no-interpolate is used.
+
+  Example:
+
+    sqsc env set -project-name staging DATABASE_URL \
+      'postgres://${DB_USERNAME}:${DB_PASSWORD}@${DB_HOST}/${DB_NAME}'
 `
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+func newPromoteGroups() (*squarescale.VariableGroup, *squarescale.VariableGroup) {
+	source := &squarescale.VariableGroup{
+		Name: "Project",
+		Variables: []*squarescale.Variable{
+			{Key: "DB_NAME", Value: "staging", Predefined: true},
+			{Key: "LOG_LEVEL", Value: "debug"},
+			{Key: "API_URL", Value: "https://api.example.com"},
+		},
+	}
+	target := &squarescale.VariableGroup{
+		Name: "Project",
+		Variables: []*squarescale.Variable{
+			{Key: "DB_NAME", Value: "production", Predefined: true},
+			{Key: "LOG_LEVEL", Value: "info"},
+			{Key: "OTHER", Value: "other"},
+		},
+	}
+	return source, target
+}
+
+func TestPromoteVariables(t *testing.T) {
+	t.Run("Test all custom variables", func(t *testing.T) {
+		source, target := newPromoteGroups()
+
+		diffs, err := promoteVariables(source, target, nil)
+
+		if err != nil {
+			t.Fatalf("Expect no error, got `%s`", err)
+		}
+		if len(diffs) != 2 || diffs[0].String(true) != "+ API_URL (custom): https://api.example.com" || diffs[1].String(true) != "~ LOG_LEVEL (custom): info -> debug" {
+			t.Errorf("Unexpected changes `%v`", diffs)
+		}
+		if v, _ := target.GetVariable("DB_NAME"); v.Value != "production" || !v.Predefined {
+			t.Errorf("Expect predefined variables not to be copied, got `%v`", v)
+		}
+		if v, _ := target.GetVariable("OTHER"); v == nil {
+			t.Error("Expect other variables to be kept")
+		}
+	})
+
+	t.Run("Test selected keys", func(t *testing.T) {
+		source, target := newPromoteGroups()
+
+		diffs, err := promoteVariables(source, target, []string{"LOG_LEVEL"})
+
+		if err != nil {
+			t.Fatalf("Expect no error, got `%s`", err)
+		}
+		if len(diffs) != 1 || diffs[0].Key != "LOG_LEVEL" {
+			t.Errorf("Unexpected changes `%v`", diffs)
+		}
+	})
+
+	t.Run("Test predefined key", func(t *testing.T) {
+		source, target := newPromoteGroups()
+
+		_, err := promoteVariables(source, target, []string{"DB_NAME"})
+
+		if err == nil || err.Error() != "Could not find custom variable 'DB_NAME' to promote" {
+			t.Errorf("Unexpected error `%v`", err)
+		}
+	})
+}
@@ -18,12 +18,13 @@ type ExternalNodeAddCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ExternalNodeAddCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	publicIP := externalNodePublicIP(cmd.flagSet)
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -70,9 +71,10 @@ func (cmd *ExternalNodeAddCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
-		cmd.runWithSpinner("wait for external node add", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		opts := cmd.waitOptions("wait for external node add", *waitTimeout)
+		res = cmd.runWithSpinner("wait for external node add", endpoint.String(), func(client *squarescale.Client) (string, error) {
 			// can also be externalNode, err := client.WaitExternalNode(UUID, externalNodeName, 5, []string{"provisionned", "inconsistent"})
-			externalNode, err := client.WaitExternalNode(UUID, externalNodeName, 5, []string{})
+			externalNode, err := client.WaitExternalNodeWithOptions(UUID, externalNodeName, []string{}, opts)
 			if err != nil {
 				return "", err
 			} else {
@@ -81,7 +83,7 @@ func (cmd *ExternalNodeAddCommand) Run(args []string) int {
 		})
 	}
 
-	return 0
+	return res
 }
 
 // Synopsis is part of cli.Command implementation.
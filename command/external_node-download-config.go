@@ -17,9 +17,9 @@ type ExternalNodeDownloadConfigCommand struct {
 
 func (cmd *ExternalNodeDownloadConfigCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	configName := externalNodeConfigNameFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
@@ -17,9 +17,9 @@ type ExternalNodeGetCommand struct {
 
 func (cmd *ExternalNodeGetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -55,6 +55,10 @@ func (cmd *ExternalNodeGetCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(externalNode)
+		}
+
 		var msg string
 		msg = ""
 		msg += fmt.Sprintf("Name:\t\t%s\n", externalNode.Name)
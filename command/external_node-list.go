@@ -4,10 +4,8 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"regexp"
 	"strings"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/squarescale/squarescale-cli/squarescale"
 	"github.com/squarescale/squarescale-cli/ui"
 )
@@ -21,14 +19,22 @@ type ExternalNodeListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ExternalNodeListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	output := listOutputFlag(cmd.flagSet, cmd.output)
+	columns := columnsFlag(cmd.flagSet)
+	sortBy := sortByFlag(cmd.flagSet)
+	noHeaders := noHeadersFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
 
+	if err := cmd.SetOutput(*output); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
 	if cmd.flagSet.NArg() > 0 {
 		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
 	}
@@ -54,30 +60,20 @@ func (cmd *ExternalNodeListCommand) Run(args []string) int {
 			return "", err
 		}
 
-		tableString := &strings.Builder{}
-		table := tablewriter.NewWriter(tableString)
-		// reset by ui/table.go FormatTable function: table.SetAutoFormatHeaders(false)
-		// seems like this should be taken into account earlier than in the ui/table.go FormatTable function to have effect on fields
-		table.SetAutoWrapText(false)
-		table.SetHeader([]string{"Name", "PublicIP", "Status"})
+		if cmd.rawOutput() {
+			return cmd.formatRaw(externalNodes)
+		}
 
+		table := ui.NewTable("Name", "PublicIP", "Status")
 		for _, en := range externalNodes {
-			table.Append([]string{
-				en.Name,
-				en.PublicIP,
-				en.Status,
-			})
+			table.Append(en.Name, en.PublicIP, en.Status)
 		}
 
 		if len(externalNodes) == 0 {
 			return "No external nodes", nil
 		}
 
-		ui.FormatTable(table)
-
-		table.Render()
-		// Remove trailing \n and HT
-		return string(regexp.MustCompile(`[\n\x09][\n\x09]*$`).ReplaceAll([]byte(tableString.String()), []byte(""))), nil
+		return cmd.renderTable(table, *columns, *sortBy, *noHeaders)
 	})
 }
 
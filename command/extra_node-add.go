@@ -18,13 +18,14 @@ type ExtraNodeAddCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ExtraNodeAddCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	nodeType := cmd.flagSet.String("node-type", "dev", "Extra-node type")
 	zone := cmd.flagSet.String("zone", "eu-west-1a", "Extra-node zone")
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -67,8 +68,9 @@ func (cmd *ExtraNodeAddCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
-		cmd.runWithSpinner("wait for extra-node add", endpoint.String(), func(client *squarescale.Client) (string, error) {
-			extraNode, err := client.WaitExtraNode(UUID, extraNodeName, 5)
+		opts := cmd.waitOptions("wait for extra-node add", *waitTimeout)
+		res = cmd.runWithSpinner("wait for extra-node add", endpoint.String(), func(client *squarescale.Client) (string, error) {
+			extraNode, err := client.WaitExtraNodeWithOptions(UUID, extraNodeName, opts)
 			if err != nil {
 				return "", err
 			} else {
@@ -77,7 +79,7 @@ func (cmd *ExtraNodeAddCommand) Run(args []string) int {
 		})
 	}
 
-	return 0
+	return res
 }
 
 // Synopsis is part of cli.Command implementation.
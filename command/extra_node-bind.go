@@ -18,9 +18,9 @@ type ExtraNodeBindCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ExtraNodeBindCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	volumeName := cmd.flagSet.String("volume-name", "", "Volume name to bind")
 
 	if err := cmd.flagSet.Parse(args); err != nil {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/ui"
 )
 
 // ExtraNodeListCommand is a cli.Command implementation for listing extra-nodes.
@@ -18,14 +19,22 @@ type ExtraNodeListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ExtraNodeListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	output := listOutputFlag(cmd.flagSet, cmd.output)
+	columns := columnsFlag(cmd.flagSet)
+	sortBy := sortByFlag(cmd.flagSet)
+	noHeaders := noHeadersFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
 
+	if err := cmd.SetOutput(*output); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
 	if *projectUUID == "" && *projectName == "" {
 		return cmd.errorWithUsage(errors.New("Project name or uuid is mandatory"))
 	}
@@ -51,20 +60,23 @@ func (cmd *ExtraNodeListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(extraNodes)
+		}
+
 		//msg doit retourner la list des extra-nodes valides : verifier si on recoit une liste des extra-nodes ou des ID
 		//msg := fmt.Sprintf("list of availables extra-nodes: %v", extra-node)
 
-		var msg string
-		msg = "Name\tNode type\tZone\t\tStatus\n"
+		table := ui.NewTable("Name", "Node type", "Zone", "Status")
 		for _, n := range extraNodes {
-			msg += fmt.Sprintf("%s\t%s\t%s\t%s\n", n.Name, n.NodeType, n.Zone, n.Status)
+			table.Append(n.Name, n.NodeType, n.Zone, n.Status)
 		}
 
 		if len(extraNodes) == 0 {
-			msg = "No extra-node found"
+			return "No extra-node found", nil
 		}
 
-		return msg, nil
+		return cmd.renderTable(table, *columns, *sortBy, *noHeaders)
 	})
 }
 
@@ -6,15 +6,75 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/cli"
 	log "github.com/sirupsen/logrus"
+	"github.com/squarescale/squarescale-cli/config"
+	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/ui"
 )
 
-type endpoint string
+// settings are the defaults of the flags resolved from the configuration
+// file, the per-directory file and the selected context
+type settings struct {
+	// context is the context selected by -context or the current one of the
+	// configuration file, explicit telling which. An explicit context takes
+	// precedence over SQSC_ENDPOINT and SQSC_ENV, the current one does not.
+	context  *config.Context
+	explicit bool
+	// user and local are the defaults of the configuration file and of the
+	// per-directory file. The defaults of the per-directory file take
+	// precedence over the ones of the context, which take precedence over
+	// the ones of the configuration file.
+	user  config.Defaults
+	local config.Defaults
+}
+
+// project returns the default project of the per-directory file, of the
+// context or of the configuration file.
+func (s settings) project() string {
+	var contextProject string
+	if s.context != nil {
+		contextProject = s.context.DefaultProject()
+	}
+	return flagDefault(s.local.DefaultProject(), contextProject, s.user.DefaultProject())
+}
+
+// organization returns the default organization of the per-directory file,
+// of the context or of the configuration file.
+func (s settings) organization() string {
+	var contextOrganization string
+	if s.context != nil {
+		contextOrganization = s.context.Organization
+	}
+	return flagDefault(s.local.Organization, contextOrganization, s.user.Organization)
+}
+
+// tokenKey returns the key of the token of the endpoint in the token store,
+// which is the token reference of the context when it targets this endpoint.
+func (s settings) tokenKey(endpoint string) string {
+	if s.context != nil && s.context.Endpoint == endpoint {
+		return s.context.TokenKey()
+	}
+	return endpoint
+}
+
+type endpoint struct {
+	value    string
+	settings settings
+}
 
 func (e *endpoint) String() string {
-	if *e == "" {
+	if e.value == "" && e.settings.context != nil && (e.settings.explicit || os.Getenv("SQSC_ENV")+os.Getenv("SQSC_ENDPOINT") == "") {
+		log.WithField("endpoint", e.settings.context.Endpoint).WithField("context", e.settings.context.Name).Debug()
+		return e.settings.context.Endpoint
+	}
+	if e.value == "" && e.settings.user.Endpoint != "" && os.Getenv("SQSC_ENV")+os.Getenv("SQSC_ENDPOINT") == "" {
+		log.WithField("endpoint", e.settings.user.Endpoint).Debug()
+		return e.settings.user.Endpoint
+	}
+	if e.value == "" {
 		env := os.Getenv("SQSC_ENV")
 		if env == "" {
 			env = "production"
@@ -31,28 +91,42 @@ func (e *endpoint) String() string {
 		log.WithField("endpoint", defaultValue).Debug()
 		return defaultValue
 	}
-	log.WithField("endpoint", *e).Debug()
-	return string(*e)
+	log.WithField("endpoint", e.value).Debug()
+	return e.value
 }
 
 func (e *endpoint) Set(value string) error {
-	normalizedEndpoint := strings.TrimSuffix(value, "/")
-	*e = endpoint(normalizedEndpoint)
+	e.value = strings.TrimSuffix(value, "/")
 	return nil
 }
 
-var endPointFlag endpoint
+// flagDefault returns the first non empty value
+func flagDefault(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
 
-func endpointFlag(f *flag.FlagSet) *endpoint {
-	f.Var(&endPointFlag, "endpoint", "SquareScale endpoint")
-	return &endPointFlag
+func endpointFlag(f *flag.FlagSet, s settings) *endpoint {
+	e := &endpoint{settings: s}
+	f.Var(e, "endpoint", "SquareScale endpoint")
+	return e
 }
 
 func projectUUIDFlag(f *flag.FlagSet) *string {
 	return f.String("project-uuid", "", "Project UUID")
 }
 
-func projectNameFlag(f *flag.FlagSet) *string {
+func projectNameFlag(f *flag.FlagSet, s settings) *string {
+	return f.String("project-name", s.project(), "Project name")
+}
+
+// explicitProjectNameFlag is projectNameFlag without the defaults, for the
+// commands destroying the project which must be named on the command line
+func explicitProjectNameFlag(f *flag.FlagSet) *string {
 	return f.String("project-name", "", "Project name")
 }
 
@@ -68,6 +142,30 @@ func regionFlag(f *flag.FlagSet) *string {
 	return f.String("region", "", "Cloud provider region name")
 }
 
+func credentialFlag(f *flag.FlagSet) *string {
+	return f.String("credential", "", "Credential used to build the infrastructure")
+}
+
+func organizationFlag(f *flag.FlagSet) *string {
+	return f.String("organization", "", "Organization of the project")
+}
+
+func showSecretsFlag(f *flag.FlagSet) *bool {
+	return f.Bool("show-secrets", false, "Export the values of secret variables instead of redacting them")
+}
+
+func cloneFromFlag(f *flag.FlagSet) *string {
+	return f.String("from", "", "Name of the project to clone")
+}
+
+func cloneToFlag(f *flag.FlagSet) *string {
+	return f.String("to", "", "Name of the project to create, prefixed by its organization if any (my-org/my-project)")
+}
+
+func skipDBFlag(f *flag.FlagSet) *bool {
+	return f.Bool("skip-db", false, "Do not create the database of the cloned project")
+}
+
 func organizationNameFlag(f *flag.FlagSet) *string {
 	return f.String("name", "", "organization name")
 }
@@ -152,6 +250,30 @@ func nowaitFlag(f *flag.FlagSet) *bool {
 	return f.Bool("nowait", false, "Don't wait for operation to complete")
 }
 
+func waitTimeoutFlag(f *flag.FlagSet) *time.Duration {
+	return f.Duration("wait-timeout", 0, "Maximum time to wait for operation to complete (ex: 30m), no limit by default")
+}
+
+func manifestFileFlag(f *flag.FlagSet) *string {
+	return f.String("f", "", "Manifest file describing the project, - for the standard input")
+}
+
+func manifestFormatFlag(f *flag.FlagSet, output string) *string {
+	format := ui.OutputYAML
+	if output == ui.OutputJSON {
+		format = ui.OutputJSON
+	}
+	return f.String("format", format, "Format of the manifest: yaml or json")
+}
+
+func envFormatFlag(f *flag.FlagSet, output string) *string {
+	format := squarescale.EnvFormatDotenv
+	if output == ui.OutputJSON || output == ui.OutputYAML {
+		format = output
+	}
+	return f.String("format", format, "Format of the variables: dotenv, json, yaml or shell")
+}
+
 func envFileFlag(f *flag.FlagSet) *string {
 	return f.String("env", "", "JSON file containing all environment variables")
 }
@@ -392,6 +514,14 @@ func envAllFlag(f *flag.FlagSet) *bool {
 	return f.Bool("all", false, "Print all variables")
 }
 
+func revealFlag(f *flag.FlagSet) *bool {
+	return f.Bool("reveal", false, "Show the values of secret variables instead of redacting them")
+}
+
+func noInterpolateFlag(f *flag.FlagSet) *bool {
+	return f.Bool("no-interpolate", false, "Set values as is, without resolving their ${VAR} and ${env:VAR} references")
+}
+
 func envRemoveFlag(f *flag.FlagSet) *bool {
 	return f.Bool("remove", false, "Remove the key from environment variables")
 }
@@ -488,3 +618,47 @@ func projectDetailsNoSchedulingGroupsFlag(f *flag.FlagSet) *bool {
 func projectDetailsNoExternalNodesFlag(f *flag.FlagSet) *bool {
 	return f.Bool("no-external-nodes", false, "Enable/Disable project detailed informations external nodes section")
 }
+
+func logsServiceFlag(f *flag.FlagSet) *string {
+	return f.String("service", "", "Service aka Docker container to display logs for")
+}
+
+func logsFollowFlag(f *flag.FlagSet) *bool {
+	return f.Bool("follow", false, "Keep waiting for new log lines until interrupted")
+}
+
+func logsSinceFlag(f *flag.FlagSet) *string {
+	return f.String("since", "", "Only display logs newer than a relative duration (ex: 10m, 1h30m)")
+}
+
+func logsLevelFlag(f *flag.FlagSet) *string {
+	return f.String("level", "", "Only display logs of this level or more severe (info, warn or error)")
+}
+
+func logsTypeFlag(f *flag.FlagSet) *string {
+	return f.String("type", "", "Only display logs of this type (docker, nomad or sqsc)")
+}
+
+func logsOutputFlag(f *flag.FlagSet) *string {
+	return f.String("output", "text", "Output format of log lines (text or json, one object per line)")
+}
+
+func watchChannelFlag(f *flag.FlagSet) *string {
+	return f.String("channel", "", "Only watch this channel (ProjectChannel, ServicesChannel or NotificationsChannel), all of them by default")
+}
+
+func listOutputFlag(f *flag.FlagSet, def string) *string {
+	return f.String("output", def, "Output format: "+strings.Join(ui.OutputFormats, ", "))
+}
+
+func columnsFlag(f *flag.FlagSet) *string {
+	return f.String("columns", "", "Comma separated list of the columns to display, all of them by default")
+}
+
+func sortByFlag(f *flag.FlagSet) *string {
+	return f.String("sort-by", "", "Column to sort the rows on")
+}
+
+func noHeadersFlag(f *flag.FlagSet) *bool {
+	return f.Bool("no-headers", false, "Do not display the header line")
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/config"
+)
+
+func newTestConfig() *config.Config {
+	return &config.Config{
+		CurrentContext: "staging",
+		Contexts: []config.Context{
+			{Name: "staging", Endpoint: "https://staging.example.com", Organization: "acme", Project: "staging"},
+			{Name: "production", Endpoint: "https://production.example.com"},
+		},
+		Defaults: config.Defaults{Endpoint: "https://www.example.com", Project: "default"},
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	t.Setenv("SQSC_ENV", "")
+	t.Setenv("SQSC_ENDPOINT", "")
+
+	t.Run("Test current context", func(t *testing.T) {
+		var meta Meta
+		if err := meta.SetConfig(newTestConfig(), config.Defaults{}, ""); err != nil {
+			t.Fatal(err)
+		}
+		e := endpoint{settings: meta.settings}
+
+		if e.String() != "https://staging.example.com" {
+			t.Errorf("Expect the endpoint of the current context, got `%s`", e.String())
+		}
+		if project := *projectNameFlag(flag.NewFlagSet("test", flag.ContinueOnError), meta.settings); project != "acme/staging" {
+			t.Errorf("Expect the project of the current context, got `%s`", project)
+		}
+		if project := *explicitProjectNameFlag(flag.NewFlagSet("test", flag.ContinueOnError)); project != "" {
+			t.Errorf("Expect no default project for destructive commands, got `%s`", project)
+		}
+
+		t.Setenv("SQSC_ENDPOINT", "https://env.example.com")
+		if e.String() != "https://env.example.com" {
+			t.Errorf("Expect SQSC_ENDPOINT to override the current context, got `%s`", e.String())
+		}
+	})
+
+	t.Run("Test per-directory defaults", func(t *testing.T) {
+		var meta Meta
+		local := config.Defaults{Context: "production", Project: "web"}
+		if err := meta.SetConfig(newTestConfig(), local, ""); err != nil {
+			t.Fatal(err)
+		}
+		e := endpoint{settings: meta.settings}
+
+		if e.String() != "https://production.example.com" {
+			t.Errorf("Expect the endpoint of the context of the directory, got `%s`", e.String())
+		}
+		f := flag.NewFlagSet("test", flag.ContinueOnError)
+		project := projectNameFlag(f, meta.settings)
+		if *project != "web" {
+			t.Errorf("Expect the project of the directory, got `%s`", *project)
+		}
+
+		f.Parse([]string{"-project-name", "api"})
+		if *project != "api" {
+			t.Errorf("Expect the flag to override the defaults, got `%s`", *project)
+		}
+	})
+
+	t.Run("Test configuration file defaults", func(t *testing.T) {
+		var meta Meta
+		c := newTestConfig()
+		c.CurrentContext = ""
+		if err := meta.SetConfig(c, config.Defaults{}, ""); err != nil {
+			t.Fatal(err)
+		}
+		e := endpoint{settings: meta.settings}
+
+		if e.String() != "https://www.example.com" {
+			t.Errorf("Expect the endpoint of the configuration file, got `%s`", e.String())
+		}
+		if project := *projectNameFlag(flag.NewFlagSet("test", flag.ContinueOnError), meta.settings); project != "default" {
+			t.Errorf("Expect the project of the configuration file, got `%s`", project)
+		}
+
+		e.Set("https://flag.example.com/")
+		if e.String() != "https://flag.example.com" {
+			t.Errorf("Expect -endpoint to override the defaults, got `%s`", e.String())
+		}
+	})
+
+	t.Run("Test explicit context", func(t *testing.T) {
+		var meta Meta
+		t.Setenv("SQSC_ENDPOINT", "https://env.example.com")
+		if err := meta.SetConfig(newTestConfig(), config.Defaults{}, "production"); err != nil {
+			t.Fatal(err)
+		}
+		e := endpoint{settings: meta.settings}
+
+		if e.String() != "https://production.example.com" {
+			t.Errorf("Expect the explicit context to override SQSC_ENDPOINT, got `%s`", e.String())
+		}
+		if err := meta.SetConfig(newTestConfig(), config.Defaults{}, "missing"); err == nil {
+			t.Error("Expect an error on unknown context")
+		}
+	})
+
+	t.Run("Test settings of several metas", func(t *testing.T) {
+		var staging, production Meta
+		if err := staging.SetConfig(newTestConfig(), config.Defaults{}, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := production.SetConfig(newTestConfig(), config.Defaults{}, "production"); err != nil {
+			t.Fatal(err)
+		}
+
+		e := endpointFlag(flag.NewFlagSet("test", flag.ContinueOnError), staging.settings)
+		if e.String() != "https://staging.example.com" {
+			t.Errorf("Expect the endpoint of the settings of the flag, got `%s`", e.String())
+		}
+		if key := production.settings.tokenKey("https://staging.example.com"); key != "https://staging.example.com" {
+			t.Errorf("Expect the endpoint as token key outside of the context, got `%s`", key)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package command
+
+import (
+	"github.com/squarescale/squarescale-cli/redact"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// redactBatches returns a copy of the batches, the values of their secret
+// variables being redacted
+func redactBatches(batches []squarescale.RunningBatch) []squarescale.RunningBatch {
+	redacted := make([]squarescale.RunningBatch, 0, len(batches))
+	for _, b := range batches {
+		b.CustomEnvironment = redact.Variables(b.CustomEnvironment)
+		b.DefaultEnvironment = redact.Variables(b.DefaultEnvironment)
+		redacted = append(redacted, b)
+	}
+	return redacted
+}
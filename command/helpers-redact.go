@@ -0,0 +1,41 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/redact"
+)
+
+// helpWidth is the width the paragraphs of the help texts are wrapped to
+const helpWidth = 78
+
+// redactionHelp returns the help paragraph describing the redaction of the
+// values introduced by intro unless flagName is used, the passwords of URLs
+// being redacted too when urls is set
+func redactionHelp(intro, flagName string, urls bool) string {
+	patterns := redact.DefaultPatterns
+	names := strings.Join(patterns[:len(patterns)-1], ", ") + " or " + patterns[len(patterns)-1]
+
+	text := intro + " whose name contains " + names +
+		", or the patterns of the " + redact.PatternsEnv + " environment variable (separated by commas)"
+	if urls {
+		text += ", and the passwords of URLs"
+	}
+	text += ", are redacted unless -" + flagName + " is used."
+	return wrapHelp(text)
+}
+
+// wrapHelp wraps text into lines indented like the paragraphs of the help
+// texts
+func wrapHelp(text string) string {
+	var lines []string
+	line := " "
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > helpWidth {
+			lines = append(lines, line)
+			line = " "
+		}
+		line += " " + word
+	}
+	return strings.Join(append(lines, line), "\n")
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"github.com/squarescale/squarescale-cli/redact"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// filterServices keeps the service with the given name, or all the services
+// if name is empty.
+func filterServices(services []squarescale.Service, name string) []squarescale.Service {
+	if name == "" {
+		return services
+	}
+
+	filtered := []squarescale.Service{}
+	for _, s := range services {
+		if s.Name == name {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// redactServices returns a copy of the services, the values of their secret
+// variables being redacted
+func redactServices(services []squarescale.Service) []squarescale.Service {
+	redacted := make([]squarescale.Service, 0, len(services))
+	for _, s := range services {
+		env := make([]squarescale.ServiceEnv, 0, len(s.CustomEnv))
+		for _, e := range s.CustomEnv {
+			e.Value = redact.Value(e.Key, e.Value)
+			env = append(env, e)
+		}
+		s.CustomEnv = env
+		redacted = append(redacted, s)
+	}
+	return redacted
+}
@@ -0,0 +1,27 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/redact"
+)
+
+func TestRedactionHelp(t *testing.T) {
+	// when
+	help := redactionHelp("The values of the variables", "reveal", true)
+
+	// then
+	for _, line := range strings.Split(help, "\n") {
+		if !strings.HasPrefix(line, "  ") || len(line) > helpWidth {
+			t.Errorf("Expect indented lines of at most %d characters, got `%s`", helpWidth, line)
+		}
+	}
+
+	text := strings.Join(strings.Fields(help), " ")
+	for _, expected := range append(redact.DefaultPatterns, redact.PatternsEnv, "passwords of URLs", "unless -reveal is used.") {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expect help to contain `%s`, got `%s`", expected, text)
+		}
+	}
+}
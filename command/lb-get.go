@@ -19,9 +19,9 @@ type LBGetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *LBGetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -60,6 +60,9 @@ func (cmd *LBGetCommand) Run(args []string) int {
 
 		var msg string
 		for _, lb := range loadBalancers {
+			if cmd.rawOutput() {
+				return cmd.formatRaw(lb)
+			}
 			msg += fmt.Sprintf("Public URL: %s\n", lb.PublicURL)
 			if lb.Active {
 				msg += fmt.Sprintf("Active: ✅\n")
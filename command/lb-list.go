@@ -22,9 +22,9 @@ type LBListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *LBListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
@@ -54,6 +54,10 @@ func (cmd *LBListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(loadBalancers)
+		}
+
 		tableString := &strings.Builder{}
 		table := tablewriter.NewWriter(tableString)
 		// reset by ui/table.go FormatTable function: table.SetAutoFormatHeaders(false)
@@ -20,9 +20,9 @@ type LBSetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *LBSetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	disableArg := loadBalancerDisableFlag(cmd.flagSet)
 	certArg := certFlag(cmd.flagSet)
 	certChainArg := certChainFlag(cmd.flagSet)
@@ -3,7 +3,6 @@ package command
 import (
 	"flag"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/squarescale/squarescale-cli/tokenstore"
@@ -19,7 +18,7 @@ type LoginCommand struct {
 func (cmd *LoginCommand) Run(args []string) int {
 	// Parse flags
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
@@ -28,22 +27,26 @@ func (cmd *LoginCommand) Run(args []string) int {
 		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
 	}
 
-	var apiKey string
-	var err error
-	apiKey = os.Getenv("SQSC_TOKEN")
+	// Retrieve credentials from the environment or from previous session
+	// (token storage)
+	apiKey, source, err := tokenstore.LookupToken(cmd.settings.tokenKey(endpoint.String()))
+	if err != nil {
+		return cmd.error(err)
+	}
 	if apiKey == "" {
-		// Retrieve credentials from previous session (token storage)
-		apiKey, err = tokenstore.GetToken(endpoint.String())
-		if err != nil || apiKey == "" {
-			// Retrieve credentials from user input
-			apiKey, err = cmd.askForCredentials()
-			if err != nil {
-				return cmd.error(err)
-			}
+		// Retrieve credentials from user input
+		apiKey, err = cmd.askForCredentials()
+		if err != nil {
+			return cmd.error(err)
 		}
+		source = "user input"
 	}
 
-	err = tokenstore.SaveToken(endpoint.String(), apiKey)
+	// Tokens given by a file or a command are managed outside of sqsc and
+	// are not copied to the token store
+	if !tokenstore.ExternalToken() {
+		err = tokenstore.SaveToken(cmd.settings.tokenKey(endpoint.String()), apiKey)
+	}
 	if err != nil {
 		return cmd.error(err)
 	} else {
@@ -51,7 +54,7 @@ func (cmd *LoginCommand) Run(args []string) int {
 		if err != nil {
 			return cmd.error(err)
 		} else {
-			cmd.Ui.Info(fmt.Sprintf("Successfully authenticated !"))
+			cmd.Ui.Info(fmt.Sprintf("Successfully authenticated with the token from %s !", source))
 			return 0
 		}
 	}
@@ -67,7 +70,17 @@ func (cmd *LoginCommand) Help() string {
 	helpText := `
 usage: sqsc login [options]
 
-  Logs the user in SquareScale platform.
+  Logs the user in SquareScale platform and saves the token in the token
+  store. The token is taken from the first of:
+
+    - the SQSC_TOKEN environment variable
+    - the file given by SQSC_TOKEN_FILE
+    - the output of the command given by SQSC_TOKEN_COMMAND
+    - the token store
+    - user input
+
+  The token is saved in the token store, unless it comes from
+  SQSC_TOKEN_FILE or SQSC_TOKEN_COMMAND which keep managing it.
 
 `
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
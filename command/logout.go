@@ -0,0 +1,79 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/config"
+	"github.com/squarescale/squarescale-cli/tokenstore"
+)
+
+// LogoutCommand is a cli.Command implementation for removing the stored token of the user.
+type LogoutCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *LogoutCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	all := cmd.flagSet.Bool("all", false, "Remove the tokens of the endpoint and of all the contexts")
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	keys := []string{cmd.settings.tokenKey(endpoint.String())}
+	if *all {
+		c, err := config.Load()
+		if err != nil {
+			return cmd.error(err)
+		}
+		for _, context := range c.Contexts {
+			keys = append(keys, context.TokenKey())
+		}
+	}
+
+	var removed []string
+	for _, key := range keys {
+		ok, err := tokenstore.RemoveToken(key)
+		if err != nil {
+			return cmd.error(err)
+		} else if ok {
+			removed = append(removed, key)
+		}
+	}
+
+	if os.Getenv("SQSC_TOKEN") != "" {
+		cmd.Ui.Warn("SQSC_TOKEN is still set and keeps being used")
+	}
+
+	if len(removed) == 0 {
+		return cmd.info("No token stored for %s", keys[0])
+	}
+	return cmd.info("Successfully logged out from %s", strings.Join(removed, ", "))
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *LogoutCommand) Synopsis() string {
+	return "logout from SquareScale platform"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *LogoutCommand) Help() string {
+	helpText := `
+usage: sqsc logout [options]
+
+  Logs the user out of SquareScale platform by removing the token of the
+  endpoint from the token store, or the tokens of the endpoint and of all
+  the contexts with -all.
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
@@ -0,0 +1,245 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/ui"
+)
+
+// logsPollInterval is the delay between two log fetches in follow mode
+const logsPollInterval = 2 * time.Second
+
+// LogsCommand is a cli.Command implementation for displaying the logs of a project service.
+type LogsCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *LogsCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	projectUUID := projectUUIDFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	container := logsServiceFlag(cmd.flagSet)
+	follow := logsFollowFlag(cmd.flagSet)
+	since := logsSinceFlag(cmd.flagSet)
+	level := logsLevelFlag(cmd.flagSet)
+	logType := logsTypeFlag(cmd.flagSet)
+	output := logsOutputFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *projectUUID == "" && *projectName == "" {
+		return cmd.errorWithUsage(errors.New("Project name or uuid is mandatory"))
+	}
+
+	if *container == "" {
+		return cmd.errorWithUsage(errors.New("Service name is mandatory"))
+	}
+
+	if *level != "" && logLevelSeverity(*level) < 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unknown log level: %v. Correct values are info, warn or error", *level))
+	}
+
+	if *logType != "" && !validLogType(*logType) {
+		return cmd.errorWithUsage(fmt.Errorf("Unknown log type: %v. Correct values are docker, nomad or sqsc", *logType))
+	}
+
+	// the global json output applies to log lines unless overridden
+	if !isFlagPassed("output", cmd.flagSet) && cmd.output == ui.OutputJSON {
+		*output = "json"
+	}
+
+	if *output != "text" && *output != "json" {
+		return cmd.errorWithUsage(fmt.Errorf("Unknown output format: %v. Correct values are text or json", *output))
+	}
+
+	var after string
+	if *since != "" {
+		duration, err := time.ParseDuration(*since)
+		if err != nil {
+			return cmd.errorWithUsage(fmt.Errorf("Invalid -since duration %q: %v", *since, err))
+		}
+		after = time.Now().Add(-duration).UTC().Format(time.RFC3339Nano)
+	}
+
+	client, err := cmd.ensureLogin(endpoint.String())
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	var UUID string
+	if *projectUUID == "" {
+		UUID, err = client.ProjectByName(*projectName)
+		if err != nil {
+			return cmd.error(err)
+		}
+	} else {
+		UUID = *projectUUID
+	}
+
+	pager := logsPager{after: after}
+	for {
+		entries, cursor, err := client.ProjectLogs(UUID, *container, pager.after)
+		if err != nil {
+			if *follow && isCancelled(err) {
+				return 0
+			}
+			return cmd.error(err)
+		}
+
+		fresh := pager.add(entries, cursor)
+		for _, entry := range fresh {
+			if !matchLogEntry(entry, *level, *logType) {
+				continue
+			}
+			if *output == "json" {
+				line, err := json.Marshal(entry)
+				if err != nil {
+					return cmd.error(err)
+				}
+				cmd.Ui.Output(string(line))
+			} else {
+				cmd.Ui.Output(fmtLogEntry(entry))
+			}
+		}
+
+		// keep paging as long as the server returns new entries
+		if len(fresh) > 0 {
+			continue
+		}
+
+		if !*follow {
+			return 0
+		}
+
+		select {
+		case <-client.Context().Done():
+			return 0
+		case <-time.After(logsPollInterval):
+		}
+	}
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *LogsCommand) Synopsis() string {
+	return "Display logs of a project service"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *LogsCommand) Help() string {
+	helpText := `
+usage: sqsc logs [options]
+
+  Display the logs of a project service aka Docker container.
+  With -follow, keep waiting for new log lines until interrupted.
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
+
+// logsPager follows the pages of logs returned by the server: after is the
+// cursor of the next page and previous holds the entries of the last page,
+// used to skip the entries returned again when the server includes the
+// cursor boundary in the next page.
+type logsPager struct {
+	after    string
+	previous map[squarescale.LogEntry]bool
+}
+
+// add records a page of logs and returns its new entries, none meaning that
+// there are no more logs for now.
+func (p *logsPager) add(entries []squarescale.LogEntry, cursor string) []squarescale.LogEntry {
+	current := make(map[squarescale.LogEntry]bool, len(entries))
+	var fresh []squarescale.LogEntry
+	for _, entry := range entries {
+		current[entry] = true
+		if !p.previous[entry] {
+			fresh = append(fresh, entry)
+		}
+	}
+
+	if len(entries) > 0 {
+		p.previous = current
+	}
+	if cursor != "" {
+		p.after = cursor
+	}
+	return fresh
+}
+
+// logLevelSeverity returns the severity associated to a level name (the
+// lower the more severe) or -1 if the level is unknown.
+func logLevelSeverity(level string) int {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "ERROR":
+		return 0
+	case "WARN":
+		return 1
+	case "INFO":
+		return 2
+	default:
+		return -1
+	}
+}
+
+// validLogType checks a log type given on the command line.
+func validLogType(logType string) bool {
+	return logType == "docker" || logType == "nomad" || logType == "sqsc"
+}
+
+// logTypeName returns the name of a log entry type as displayed and filtered
+// on the command line.
+func logTypeName(entry squarescale.LogEntry) string {
+	if entry.Type == "event" {
+		return "sqsc"
+	}
+	return entry.Type
+}
+
+// matchLogEntry filters a log entry on its level and type.
+func matchLogEntry(entry squarescale.LogEntry, level, logType string) bool {
+	if level != "" && logLevelSeverity(entry.LevelName()) > logLevelSeverity(level) {
+		return false
+	}
+
+	if logType != "" && logTypeName(entry) != logType {
+		return false
+	}
+
+	return true
+}
+
+// fmtLogEntry renders a log entry as "<time> <type> [<container>] -- [<level>] <message>"
+// colored when the entry is an error.
+func fmtLogEntry(entry squarescale.LogEntry) string {
+	var containerWithBrackets string
+	if entry.ContainerName != "" {
+		containerWithBrackets = "[" + entry.ContainerName + "] "
+	}
+
+	linePattern := "%s %-6s %s-- [%-5s] %s"
+	if entry.Error {
+		linePattern = "\033[0;33m" + linePattern + "\033[0m"
+	}
+
+	timestamp := entry.Timestamp
+	t, err := entry.Time()
+	if err == nil {
+		timestamp = fmt.Sprintf("%-23s", t.Format("2006-01-02 15:04:05.999"))
+	}
+
+	return fmt.Sprintf(linePattern, timestamp, logTypeName(entry), containerWithBrackets, entry.LevelName(), entry.Message)
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+func logEntry(timestamp, message string) squarescale.LogEntry {
+	return squarescale.LogEntry{Timestamp: timestamp, ContainerName: "web", Type: "docker", Message: message, Level: 6}
+}
+
+func messages(entries []squarescale.LogEntry) []string {
+	var list []string
+	for _, entry := range entries {
+		list = append(list, entry.Message)
+	}
+	return list
+}
+
+func expectMessages(t *testing.T, entries []squarescale.LogEntry, expected ...string) {
+	t.Helper()
+	actual := messages(entries)
+	if len(actual) != len(expected) {
+		t.Fatalf("Expect messages %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("Expect messages %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestLogsPager(t *testing.T) {
+	t.Run("Test paging", func(t *testing.T) {
+		pager := logsPager{after: "since"}
+
+		fresh := pager.add([]squarescale.LogEntry{
+			logEntry("2023-08-17T10:00:01Z", "one"),
+			logEntry("2023-08-17T10:00:02Z", "two"),
+		}, "2023-08-17T10:00:02Z")
+		expectMessages(t, fresh, "one", "two")
+		if pager.after != "2023-08-17T10:00:02Z" {
+			t.Errorf("Expect the cursor of the page, got `%s`", pager.after)
+		}
+
+		fresh = pager.add([]squarescale.LogEntry{
+			logEntry("2023-08-17T10:00:02Z", "two"),
+			logEntry("2023-08-17T10:00:03Z", "three"),
+		}, "2023-08-17T10:00:03Z")
+		expectMessages(t, fresh, "three")
+
+		fresh = pager.add([]squarescale.LogEntry{
+			logEntry("2023-08-17T10:00:03Z", "three"),
+		}, "2023-08-17T10:00:03Z")
+		expectMessages(t, fresh)
+	})
+
+	t.Run("Test identical lines at other times", func(t *testing.T) {
+		pager := logsPager{}
+
+		fresh := pager.add([]squarescale.LogEntry{
+			logEntry("2023-08-17T10:00:01Z", "ping"),
+			logEntry("2023-08-17T10:00:02Z", "ping"),
+		}, "2023-08-17T10:00:02Z")
+		expectMessages(t, fresh, "ping", "ping")
+
+		fresh = pager.add([]squarescale.LogEntry{
+			logEntry("2023-08-17T10:00:02Z", "ping"),
+			logEntry("2023-08-17T10:00:03Z", "ping"),
+		}, "2023-08-17T10:00:03Z")
+		expectMessages(t, fresh, "ping")
+	})
+
+	t.Run("Test empty page", func(t *testing.T) {
+		pager := logsPager{after: "since"}
+
+		fresh := pager.add(nil, "")
+		expectMessages(t, fresh)
+		if pager.after != "since" {
+			t.Errorf("Expect the cursor to be kept, got `%s`", pager.after)
+		}
+
+		pager.add([]squarescale.LogEntry{logEntry("2023-08-17T10:00:01Z", "one")}, "2023-08-17T10:00:01Z")
+		pager.add(nil, "")
+		fresh = pager.add([]squarescale.LogEntry{logEntry("2023-08-17T10:00:01Z", "one")}, "2023-08-17T10:00:01Z")
+		expectMessages(t, fresh)
+	})
+}
+
+func TestMatchLogEntry(t *testing.T) {
+	entry := squarescale.LogEntry{Type: "event", Level: 4}
+
+	for _, c := range []struct {
+		level, logType string
+		expected       bool
+	}{
+		{"", "", true},
+		{"info", "", true},
+		{"warn", "", true},
+		{"error", "", false},
+		{"", "sqsc", true},
+		{"", "docker", false},
+	} {
+		if matchLogEntry(entry, c.level, c.logType) != c.expected {
+			t.Errorf("Expect matchLogEntry(%s, %s) to be %v", c.level, c.logType, c.expected)
+		}
+	}
+}
+
+func TestFmtLogEntry(t *testing.T) {
+	line := fmtLogEntry(logEntry("2023-08-17T10:00:01.5Z", "hello"))
+
+	expected := "2023-08-17 10:00:01.5   docker [web] -- [INFO ] hello"
+	if line != expected {
+		t.Errorf("Expect `%s`, got `%s`", expected, line)
+	}
+}
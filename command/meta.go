@@ -1,32 +1,60 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
 	"github.com/mattn/go-isatty"
 	"github.com/mitchellh/cli"
+	"github.com/squarescale/squarescale-cli/config"
 	"github.com/squarescale/squarescale-cli/squarescale"
 	"github.com/squarescale/squarescale-cli/tokenstore"
+	"github.com/squarescale/squarescale-cli/ui"
 )
 
 var CancelledError error = errors.New("Cancelled")
+
+// Exit codes of the commands, so that scripts can tell failures apart.
+const (
+	ExitError        = 1
+	ExitCancelled    = 2
+	ExitNotFound     = 3
+	ExitConflict     = 4
+	ExitUnauthorized = 5
+	ExitValidation   = 6
+	// ExitDrift is returned by plan when the project differs from its manifest
+	ExitDrift = 7
+)
+
 var IsTTY bool
 
+var (
+	interruptOnce sync.Once
+	interruptCtx  context.Context
+)
+
 func init() {
 	IsTTY = isatty.IsTerminal(os.Stdout.Fd())
 }
 
 // Meta contain the meta-option that nearly all subcommand inherited.
 type Meta struct {
-	Ui         cli.Ui
-	spin       *spinner.Spinner
-	spinEnable bool
-	niceFormat bool
+	Ui                 cli.Ui
+	spin               *spinner.Spinner
+	spinEnable         bool
+	niceFormat         bool
+	retryMax           int
+	retryNonIdempotent bool
+	waitEvents         bool
+	output             string
+	settings           settings
 }
 
 // DefaultMeta returns a default meta object with an initialized spinner.
@@ -52,22 +80,143 @@ func DefaultMeta(ui cli.Ui, color, niceFormat, spinnerEnable bool, spinTime time
 	}
 }
 
+// SetRetry configures the retries of the API calls on transient failures.
+func (meta *Meta) SetRetry(maxRetries int, nonIdempotent bool) {
+	meta.retryMax = maxRetries
+	meta.retryNonIdempotent = nonIdempotent
+}
+
+// SetWaitEvents makes the waiters check the awaited resources as soon as a
+// project event is received, on top of the regular polling.
+func (meta *Meta) SetWaitEvents(enabled bool) {
+	meta.waitEvents = enabled
+}
+
+// SetOutput sets the output format of the read commands. The progress spinner
+// is disabled for machine-readable formats so that it does not mix with data.
+func (meta *Meta) SetOutput(format string) error {
+	if !ui.ValidOutputFormat(format) {
+		return fmt.Errorf("Unknown output format: %s. Correct values are %s", format, strings.Join(ui.OutputFormats, ", "))
+	}
+
+	meta.output = format
+	if meta.rawOutput() {
+		meta.spinEnable = false
+	}
+	return nil
+}
+
+// SetConfig sets the defaults of the common flags given by the configuration
+// file and by the per-directory file, and selects the context whose endpoint,
+// token and defaults are used by the commands: the given one, or the one of
+// the defaults, or the current one of the configuration file.
+func (meta *Meta) SetConfig(c *config.Config, local config.Defaults, contextName string) error {
+	s := settings{user: c.Defaults, local: local, explicit: contextName != ""}
+
+	var err error
+	if s.explicit {
+		s.context, err = c.Context(contextName)
+	} else if name := flagDefault(local.Context, c.Defaults.Context); name != "" {
+		s.context, err = c.Context(name)
+	} else {
+		s.context, err = c.Current()
+	}
+	if err != nil {
+		return err
+	}
+	meta.settings = s
+	return nil
+}
+
+// rawOutput tells whether read commands print their data in a
+// machine-readable format instead of tables.
+func (meta *Meta) rawOutput() bool {
+	return meta.output != "" && meta.output != ui.OutputTable
+}
+
+// formatRaw renders the data of a read command in the requested
+// machine-readable format.
+func (meta *Meta) formatRaw(data interface{}) (string, error) {
+	return ui.FormatOutput(meta.output, data)
+}
+
+// renderTable renders the table of a list command with the selected columns,
+// sorted on the sortBy column if any.
+func (meta *Meta) renderTable(table *ui.Table, columns, sortBy string, noHeaders bool) (string, error) {
+	if sortBy != "" {
+		if err := table.SortBy(sortBy); err != nil {
+			return "", err
+		}
+	}
+
+	if columns != "" {
+		if err := table.SelectColumns(strings.Split(columns, ",")); err != nil {
+			return "", err
+		}
+	}
+
+	if !meta.niceFormat {
+		return table.RenderText(!noHeaders), nil
+	}
+	return table.Render(!noHeaders), nil
+}
+
 func (meta *Meta) info(message string, args ...interface{}) int {
 	meta.Ui.Info(fmt.Sprintf(message, args...))
 	return 0
 }
 
+// interruptContext returns a context cancelled on the first Ctrl-C so that
+// in-flight API calls and waits stop cleanly. A second Ctrl-C kills the
+// process as usual.
+func interruptContext() context.Context {
+	interruptOnce.Do(func() {
+		var cancel context.CancelFunc
+		interruptCtx, cancel = context.WithCancel(context.Background())
+
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		go func() {
+			<-interrupt
+			signal.Stop(interrupt)
+			cancel()
+		}()
+	})
+	return interruptCtx
+}
+
+// isCancelled tells whether err comes from the user cancelling the command.
+func isCancelled(err error) bool {
+	return err == CancelledError || errors.Is(err, context.Canceled)
+}
+
 func (meta *Meta) error(err error) int {
-	if err == CancelledError {
+	if isCancelled(err) {
 		return meta.cancelled()
 	} else {
 		meta.Ui.Error(err.Error())
-		return 1
+		return exitCode(err)
 	}
 }
 
 func (meta *Meta) cancelled() int {
-	return 2
+	return ExitCancelled
+}
+
+// exitCode returns the exit code matching the kind of an API error.
+func exitCode(err error) int {
+	switch {
+	case squarescale.IsNotFound(err):
+		return ExitNotFound
+	case squarescale.IsConflict(err):
+		return ExitConflict
+	case squarescale.IsUnauthorized(err):
+		return ExitUnauthorized
+	case squarescale.IsValidation(err):
+		return ExitValidation
+	default:
+		return ExitError
+	}
 }
 
 func (meta *Meta) errorWithUsage(err error) int {
@@ -92,13 +241,34 @@ func (meta *Meta) runWithSpinner(text, endpoint string, action func(*squarescale
 	}
 
 	meta.stopSpinner()
-	if finalMsg != "" {
+	if finalMsg != "" && meta.rawOutput() {
+		meta.Ui.Output(finalMsg)
+		return 0
+	} else if finalMsg != "" {
 		return meta.info(finalMsg)
 	} else {
 		return 0
 	}
 }
 
+// waitOptions returns the options of the waiters run under the spinner,
+// reporting the observed status next to the spinner text. With SetWaitEvents,
+// the project events also trigger a poll as soon as they are received.
+func (meta *Meta) waitOptions(text string, timeout time.Duration) squarescale.WaitOptions {
+	opts := squarescale.DefaultWaitOptions()
+	opts.Timeout = timeout
+	opts.Push = meta.waitEvents
+	opts.Progress = func(status string) {
+		if !meta.spinEnable || status == "" {
+			return
+		}
+		meta.spin.Lock()
+		meta.spin.Suffix = fmt.Sprintf(" %s (%s)", text, status)
+		meta.spin.Unlock()
+	}
+	return opts
+}
+
 func (meta *Meta) startSpinner() {
 	if !meta.spinEnable {
 		return
@@ -131,7 +301,7 @@ func (meta *Meta) errorSpinner(err error) {
 		return
 	}
 
-	if err == CancelledError {
+	if isCancelled(err) {
 		meta.spin.FinalMSG = "... cancelled\n"
 	} else {
 		meta.spin.FinalMSG = "... error\n"
@@ -140,12 +310,17 @@ func (meta *Meta) errorSpinner(err error) {
 }
 
 func (meta *Meta) ensureLogin(endpoint string) (*squarescale.Client, error) {
-	token, err := tokenstore.GetToken(endpoint)
+	token, err := tokenstore.GetToken(meta.settings.tokenKey(endpoint))
 	if err != nil {
 		return nil, err
 	}
 
-	client := squarescale.NewClient(endpoint, token)
+	client := squarescale.NewClient(endpoint, token).WithContext(interruptContext())
+	policy := client.RetryPolicy()
+	policy.MaxRetries = meta.retryMax
+	policy.RetryNonIdempotent = meta.retryNonIdempotent
+	client.SetRetryPolicy(policy)
+
 	user, err := client.ValidateToken()
 	if err != nil {
 		return nil, err
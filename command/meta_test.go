@@ -0,0 +1,20 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestWaitOptions(t *testing.T) {
+	meta := DefaultMeta(cli.NewMockUi(), false, false, false, 0)
+
+	if meta.waitOptions("wait", 0).Push {
+		t.Error("Expect waits to poll only by default")
+	}
+
+	meta.SetWaitEvents(true)
+	if !meta.waitOptions("wait", 0).Push {
+		t.Error("Expect waits to follow the project events with SetWaitEvents")
+	}
+}
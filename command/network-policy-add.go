@@ -17,9 +17,9 @@ type NetworkPolicyAddCommand struct {
 
 func (cmd *NetworkPolicyAddCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	policyName := networkPolicyNameFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
@@ -17,9 +17,9 @@ type NetworkPolicyDeleteCommand struct {
 
 func (cmd *NetworkPolicyDeleteCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -19,9 +19,9 @@ type NetworkPolicyGetCommand struct {
 
 func (cmd *NetworkPolicyGetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	jsonFormat := jsonFormatFlag(cmd.flagSet)
 	dumpFlag := networkPolicyDumpFlag(cmd.flagSet)
 
@@ -69,6 +69,13 @@ func (cmd *NetworkPolicyGetCommand) Run(args []string) int {
 			}
 		}
 
+		if cmd.rawOutput() {
+			if policy.IsLoaded() {
+				return cmd.formatRaw(policy)
+			}
+			return cmd.formatRaw(map[string]interface{}{})
+		}
+
 		if *jsonFormat {
 			if policy.IsLoaded() {
 				j, _ := json.Marshal(policy)
@@ -19,9 +19,9 @@ type NetworkPolicyListCommand struct {
 
 func (cmd *NetworkPolicyListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	jsonFormat := jsonFormatFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
@@ -67,6 +67,10 @@ func (cmd *NetworkPolicyListCommand) Run(args []string) int {
 			buffer.WriteString("\n")
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(versions)
+		}
+
 		if len(versions) == 0 {
 			buffer.WriteString("No network policies found")
 		} else {
@@ -18,9 +18,9 @@ type NetworkRuleCreateCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *NetworkRuleCreateCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	ruleName := networkRuleNameFlag(cmd.flagSet)
 	serviceName := networkServiceNameFlag(cmd.flagSet)
 	externalProtocol := networkExternalProtocolFlag(cmd.flagSet)
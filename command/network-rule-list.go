@@ -18,9 +18,9 @@ type NetworkRuleListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *NetworkRuleListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	serviceName := networkServiceNameFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
@@ -56,6 +56,10 @@ func (cmd *NetworkRuleListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(rules)
+		}
+
 		var msg string = "Name\tInt. Proto/Port\tExt. Proto/Port\tDomain\tPath. Prefix\n"
 		for _, c := range rules {
 			msg += fmt.Sprintf("%s\t%s/%d\t%s/%d\t%s\t%s\n", c.Name, c.InternalProtocol, c.InternalPort, c.ExternalProtocol, c.ExternalPort, c.DomainExpression, c.PathPrefix)
@@ -17,7 +17,7 @@ type OrganizationAddCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *OrganizationAddCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	name := organizationNameFlag(cmd.flagSet)
 	email := organizationEmailFlag(cmd.flagSet)
 
@@ -18,7 +18,7 @@ type OrganizationDeleteCommand struct {
 func (cmd *OrganizationDeleteCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
 	alwaysYes := yesFlag(cmd.flagSet)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	name := organizationNameFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
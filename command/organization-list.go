@@ -17,7 +17,7 @@ type OrganizationListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *OrganizationListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -34,6 +34,10 @@ func (cmd *OrganizationListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(organizations)
+		}
+
 		var msg string
 
 		for _, o := range organizations {
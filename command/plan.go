@@ -0,0 +1,95 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/manifest"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// PlanCommand is a cli.Command implementation for showing the differences
+// between a manifest file and the actual state of its project.
+type PlanCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *PlanCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	file := manifestFileFlag(cmd.flagSet)
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *file == "" {
+		return cmd.errorWithUsage(errors.New("Manifest file is mandatory"))
+	}
+
+	m, err := manifest.Load(*file)
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	var plan *manifest.Plan
+	res := cmd.runWithSpinner("compute changes", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		var err error
+		plan, err = manifest.NewPlan(client, m)
+		if err != nil {
+			return "", err
+		}
+
+		if cmd.rawOutput() {
+			changes := plan.Changes
+			if changes == nil {
+				changes = []manifest.Change{}
+			}
+			return cmd.formatRaw(changes)
+		}
+
+		if !plan.HasChanges() {
+			return fmt.Sprintf("Project '%s' is up to date", m.Project.Name), nil
+		}
+		return plan.String(), nil
+	})
+	if res != 0 {
+		return res
+	}
+
+	if plan.HasChanges() {
+		return ExitDrift
+	}
+	return 0
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *PlanCommand) Synopsis() string {
+	return "Show the differences between a manifest file and its project"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *PlanCommand) Help() string {
+	helpText := `
+usage: sqsc plan -f project.yaml [options]
+
+  Compares the project described by the manifest file (see sqsc apply) with
+  its actual state and prints the resources to create (+), update (~) with
+  the changed fields, the existing ones missing from the manifest (-), and
+  the ones differing on settings which can not be changed once created,
+  which sqsc apply refuses to replace (-/+).
+  Environment variable values are never shown.
+
+  Exits with status 7 when the project differs from its manifest, 0 when it
+  is up to date, so that it can be used to check for drift in CI.
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
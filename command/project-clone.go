@@ -0,0 +1,224 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/config"
+	"github.com/squarescale/squarescale-cli/manifest"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// ProjectCloneCommand is a cli.Command implementation for creating a new
+// project from an existing one.
+type ProjectCloneCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ProjectCloneCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	from := cloneFromFlag(cmd.flagSet)
+	to := cloneToFlag(cmd.flagSet)
+	region := regionFlag(cmd.flagSet)
+	provider := providerFlag(cmd.flagSet)
+	credential := credentialFlag(cmd.flagSet)
+	skipDB := skipDBFlag(cmd.flagSet)
+	alwaysYes := yesFlag(cmd.flagSet)
+	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *from == "" {
+		return cmd.errorWithUsage(errors.New("Project to clone is mandatory"))
+	}
+
+	if *to == "" {
+		return cmd.errorWithUsage(errors.New("Name of the new project is mandatory"))
+	}
+
+	organization, name := "", *to
+	if i := strings.Index(*to, "/"); i >= 0 {
+		organization, name = (*to)[:i], (*to)[i+1:]
+	}
+	if err := validateProjectName(name); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
+	var m *manifest.Manifest
+	var sourceUUID string
+	res := cmd.runWithSpinner("export project", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		var err error
+		sourceUUID, err = client.ProjectByName(*from)
+		if err != nil {
+			return "", err
+		}
+
+		m, err = manifest.Export(client, sourceUUID, true)
+		return "", err
+	})
+	if res != 0 {
+		return res
+	}
+
+	m.Project.Name = name
+	if organization != "" {
+		m.Project.Organization = organization
+	}
+	if *provider != "" {
+		m.Project.Provider = *provider
+	}
+	if *region != "" {
+		m.MoveToRegion(*region)
+	}
+	if *credential != "" {
+		m.Project.Credential = *credential
+	}
+	if *skipDB {
+		m.Database = nil
+	}
+
+	if m.Project.FullName() == *from {
+		return cmd.errorWithUsage(errors.New("Cannot clone a project into itself"))
+	}
+
+	if err := m.Validate(); err != nil {
+		return cmd.error(err)
+	}
+
+	if err := m.ResolveImageCredentials(os.LookupEnv); err != nil {
+		return cmd.error(err)
+	}
+
+	var UUID string
+	res = cmd.runWithSpinner("look up new project", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		var err error
+		UUID, err = client.ProjectByName(m.Project.FullName())
+		if squarescale.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	})
+	if res != 0 {
+		return res
+	}
+
+	// only a clone of the same project, interrupted, is resumed
+	exists := UUID != ""
+	if exists {
+		source, err := config.CloneSource(UUID)
+		if err != nil {
+			return cmd.error(err)
+		}
+		if source != sourceUUID {
+			return cmd.error(fmt.Errorf("Project '%s' already exists and is not a clone of '%s' in progress", m.Project.FullName(), *from))
+		}
+	}
+
+	question := fmt.Sprintf("Clone project '%s' into '%s'?", *from, m.Project.FullName())
+	if exists {
+		question = fmt.Sprintf("Project '%s' already exists, resume the clone of '%s'?", m.Project.FullName(), *from)
+	}
+	ok, err := AskYesNo(cmd.Ui, alwaysYes, question, false)
+	if err != nil {
+		return cmd.error(err)
+	} else if !ok {
+		return cmd.cancelled()
+	}
+	yes := true
+
+	// the project is created and provisioned first, the other resources
+	// being created afterwards: running the command again after an
+	// interruption only creates what is still missing
+	if !exists {
+		res = cmd.runWithSpinner("create project", endpoint.String(), func(client *squarescale.Client) (string, error) {
+			plan, err := manifest.NewPlan(client, m.ProjectOnly())
+			if err != nil {
+				return "", err
+			}
+
+			// the clone is recorded as soon as the project exists, so
+			// that it is resumed even if the rest of the creation fails
+			_, err = plan.Apply(client)
+			if plan.ProjectUUID == "" {
+				return "", err
+			}
+			UUID = plan.ProjectUUID
+			if recordErr := config.StartClone(UUID, sourceUUID); recordErr != nil {
+				return "", recordErr
+			}
+			return "", err
+		})
+		if res != 0 {
+			return res
+		}
+	}
+
+	res = cmd.runWithSpinner("wait for project", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		_, err := client.WaitProjectWithOptions(UUID, cmd.waitOptions("wait for project", *waitTimeout))
+		return "", err
+	})
+	if res != 0 {
+		return res
+	}
+
+	res = cmd.applyManifest(endpoint.String(), m, &yes, *nowait, *waitTimeout)
+	if res != 0 {
+		return res
+	}
+	if err := config.FinishClone(UUID); err != nil {
+		return cmd.error(err)
+	}
+	return 0
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ProjectCloneCommand) Synopsis() string {
+	return "Clone a project into a new one"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ProjectCloneCommand) Help() string {
+	helpText := `
+usage: sqsc project clone -from project -to new-project [options]
+
+  Creates a new project with the infrastructure and database of an existing
+  one, waits for it to be provisioned, then recreates the redis, volumes,
+  extra nodes, scheduling groups, services with their network rules,
+  batches, network policy and custom environment of the cloned project,
+  secret values included.
+
+  The region, provider and credential of the cloned project are used unless
+  -region, -provider or -credential is given. When another region is given,
+  volumes and extra nodes are moved to the zones of the same name in this
+  region (eu-west-1a becomes us-east-1a).
+
+  The credentials of private Docker images can not be read from the API,
+  they are taken from the SQSC_IMAGE_USERNAME_<SERVICE> and
+  SQSC_IMAGE_PASSWORD_<SERVICE> environment variables, such as
+  SQSC_IMAGE_PASSWORD_MY_WEB for the service my-web.
+
+  The clone can safely be run again after an interruption: only the
+  resources still missing from the new project are created. The clones in
+  progress are recorded in clones.yaml, next to the configuration file, and
+  an existing project is only resumed when it is an unfinished clone of the
+  same project.
+
+  Example:
+
+    sqsc project clone -from staging -to staging-2 -skip-db
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
@@ -20,11 +20,11 @@ type ProjectCreateCommand struct {
 func (cmd *ProjectCreateCommand) Run(args []string) int {
 	// Parse flags
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	alwaysYes := yesFlag(cmd.flagSet)
-	name := projectNameFlag(cmd.flagSet)
+	name := cmd.flagSet.String("project-name", "", "Project name")
 	uuid := cmd.flagSet.String("uuid", "", "set the uuid of the project")
-	organization := cmd.flagSet.String("organization", "", "set the organization the project will belongs to")
+	organization := cmd.flagSet.String("organization", cmd.settings.organization(), "set the organization the project will belongs to")
 	provider := cmd.flagSet.String("provider", "", "set the cloud provider (aws or azure or outscale)")
 	region := cmd.flagSet.String("region", "", "set the cloud provider region (eu-west-1)")
 	credential := cmd.flagSet.String("credential", "", "set the credential used to build the infrastructure")
@@ -68,6 +68,7 @@ func (cmd *ProjectCreateCommand) Run(args []string) int {
 	elasticsearchIpWhiteList := cmd.flagSet.String("elasticsearch-ip-whitelist", "", "Set ElasticSearch IP whitelist")
 
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 
 	payload := squarescale.JSONObject{}
 
@@ -255,8 +256,9 @@ func (cmd *ProjectCreateCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
+		opts := cmd.waitOptions("wait for project creation", *waitTimeout)
 		res = cmd.runWithSpinner("wait for project creation", endpoint.String(), func(client *squarescale.Client) (string, error) {
-			projectStatus, err := client.WaitProject(project.UUID, 5)
+			projectStatus, err := client.WaitProjectWithOptions(project.UUID, opts)
 			if err != nil {
 				return projectStatus, err
 			} else {
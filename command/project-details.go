@@ -23,9 +23,9 @@ type ProjectDetailsCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ProjectDetailsCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	noSummary := projectDetailsNoSummaryFlag(cmd.flagSet)
 	noComputeResources := projectDetailsNoComputeResourcesFlag(cmd.flagSet)
 	noSchedulingGroups := projectDetailsNoSchedulingGroupsFlag(cmd.flagSet)
@@ -58,6 +58,9 @@ func (cmd *ProjectDetailsCommand) Run(args []string) int {
 		if err != nil {
 			return "", err
 		}
+		if cmd.rawOutput() {
+			return cmd.formatRaw(projectDetails)
+		}
 		if projectDetails == nil {
 			return "No details to show", nil
 		}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/manifest"
+	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/ui"
+)
+
+// ProjectExportCommand is a cli.Command implementation for describing an
+// existing project as a manifest.
+type ProjectExportCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ProjectExportCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	projectUUID := projectUUIDFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	format := manifestFormatFlag(cmd.flagSet, cmd.output)
+	showSecrets := showSecretsFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if *projectUUID == "" && *projectName == "" {
+		return cmd.errorWithUsage(errors.New("Project name or uuid is mandatory"))
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *format != ui.OutputYAML && *format != ui.OutputJSON {
+		return cmd.errorWithUsage(fmt.Errorf("Unknown format: %s. Correct values are yaml or json", *format))
+	}
+	// disables the spinner so that it does not mix with the document
+	if err := cmd.SetOutput(*format); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
+	var redacted []string
+	res := cmd.runWithSpinner("export project", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		var UUID string
+		var err error
+		if *projectUUID == "" {
+			UUID, err = client.ProjectByName(*projectName)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			UUID = *projectUUID
+		}
+
+		m, err := manifest.Export(client, UUID, *showSecrets)
+		if err != nil {
+			return "", err
+		}
+		redacted = m.RedactedValues()
+
+		var data []byte
+		if *format == ui.OutputJSON {
+			data, err = m.MarshalIndentJSON()
+		} else {
+			data, err = m.Marshal()
+		}
+		return strings.TrimSuffix(string(data), "\n"), err
+	})
+	if res != 0 {
+		return res
+	}
+
+	if len(redacted) > 0 {
+		cmd.Ui.Warn(fmt.Sprintf("Redacted secret values: %s", strings.Join(redacted, ", ")))
+	}
+	return 0
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ProjectExportCommand) Synopsis() string {
+	return "Export a project as a manifest"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ProjectExportCommand) Help() string {
+	helpText := `
+usage: sqsc project export [options]
+
+  Describes an existing project as a manifest (see sqsc apply) on the
+  standard output: infrastructure, database, redis, volumes, extra nodes,
+  scheduling groups, services with their network rules, batches, network
+  policy and environment.
+
+` + redactionHelp("The values of the variables", "show-secrets", false) + `
+  Redacted values are left unchanged when the manifest is imported or applied.
+
+  The credentials of private Docker images, which the API never returns, are
+  exported as references to the SQSC_IMAGE_USERNAME_<SERVICE> and
+  SQSC_IMAGE_PASSWORD_<SERVICE> environment variables, which must be set when
+  the manifest is imported or applied.
+
+  Example:
+
+    sqsc project export -project-name staging > staging.yaml
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
@@ -20,9 +20,9 @@ type ProjectGetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ProjectGetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -51,6 +51,9 @@ func (cmd *ProjectGetCommand) Run(args []string) int {
 		if err != nil {
 			return "", err
 		}
+		if cmd.rawOutput() {
+			return cmd.formatRaw(project)
+		}
 
 		policy, err := client.GetNetworkPolicy(UUID, "")
 		if err != nil {
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/manifest"
+)
+
+// ProjectImportCommand is a cli.Command implementation for recreating a
+// project from an exported manifest.
+type ProjectImportCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *ProjectImportCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	file := manifestFileFlag(cmd.flagSet)
+	projectName := explicitProjectNameFlag(cmd.flagSet)
+	organization := organizationFlag(cmd.flagSet)
+	credential := credentialFlag(cmd.flagSet)
+	alwaysYes := yesFlag(cmd.flagSet)
+	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *file == "" {
+		return cmd.errorWithUsage(errors.New("Manifest file is mandatory"))
+	}
+
+	if *projectName != "" {
+		if err := validateProjectName(*projectName); err != nil {
+			return cmd.errorWithUsage(err)
+		}
+	}
+
+	m, err := manifest.Load(*file)
+	if err != nil {
+		return cmd.error(err)
+	}
+
+	if *projectName != "" {
+		m.Project.Name = *projectName
+	}
+	if *organization != "" {
+		m.Project.Organization = *organization
+	}
+	if *credential != "" {
+		m.Project.Credential = *credential
+	}
+
+	if redacted := m.RedactedValues(); len(redacted) > 0 {
+		cmd.Ui.Warn(fmt.Sprintf("Redacted values are left unchanged, or unset on new resources: %s", strings.Join(redacted, ", ")))
+	}
+
+	return cmd.applyManifest(endpoint.String(), m, alwaysYes, *nowait, *waitTimeout)
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *ProjectImportCommand) Synopsis() string {
+	return "Import a project from a manifest"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *ProjectImportCommand) Help() string {
+	helpText := `
+usage: sqsc project import -f project.yaml [options]
+
+  Creates or updates a project from a manifest exported by sqsc project
+  export, the project name, organization and credential of the manifest
+  being replaced by the given ones if any.
+
+  Secret values redacted on export are left unchanged on existing resources
+  and are not set on new ones: set them afterwards, or export with
+  -show-secrets.
+
+  The credentials of private Docker images, exported as references to the
+  SQSC_IMAGE_USERNAME_<SERVICE> and SQSC_IMAGE_PASSWORD_<SERVICE> environment
+  variables, are read from these variables, such as SQSC_IMAGE_PASSWORD_MY_WEB
+  for the service my-web. The import fails when one of them is not set.
+
+  Example, cloning staging into a new project:
+
+    sqsc project export -project-name staging > staging.yaml
+    sqsc project import -f staging.yaml -project-name staging-2
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
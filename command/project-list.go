@@ -3,12 +3,10 @@ package command
 import (
 	"flag"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 
 	"github.com/BenJetson/humantime"
-	"github.com/olekukonko/tablewriter"
 	"github.com/squarescale/squarescale-cli/squarescale"
 	"github.com/squarescale/squarescale-cli/ui"
 )
@@ -22,11 +20,19 @@ type ProjectListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ProjectListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	output := listOutputFlag(cmd.flagSet, cmd.output)
+	columns := columnsFlag(cmd.flagSet)
+	sortBy := sortByFlag(cmd.flagSet)
+	noHeaders := noHeadersFlag(cmd.flagSet)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
 
+	if err := cmd.SetOutput(*output); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
 	if cmd.flagSet.NArg() > 0 {
 		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
 	}
@@ -53,15 +59,19 @@ func (cmd *ProjectListCommand) Run(args []string) int {
 			}
 		}
 
-		var msg string
+		for _, o := range organizations {
+			projects = append(projects, o.Projects...)
+		}
+
+		if cmd.rawOutput() {
+			return cmd.formatRaw(projects)
+		}
 
 		if projectCount == 0 {
-			msg = "No projects found"
-		} else {
-			msg = fmtProjectListOutput(projects, organizations)
+			return "No projects found", nil
 		}
 
-		return msg, nil
+		return cmd.renderTable(projectListTable(projects), *columns, *sortBy, *noHeaders)
 	})
 }
 
@@ -80,13 +90,9 @@ usage: sqsc project list [options]
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
 
-func fmtProjectListOutput(projects []squarescale.Project, organizations []squarescale.Organization) string {
-	tableString := &strings.Builder{}
-	table := tablewriter.NewWriter(tableString)
-	// reset by ui/table.go FormatTable function: table.SetAutoFormatHeaders(false)
-	// seems like this should be taken into account earlier than in the ui/table.go FormatTable function to have effect on fields
-	table.SetAutoWrapText(false)
-	table.SetHeader([]string{"Name", "UUID", "Monitoring", "Provider", "Credentials", "Region", "Organization", "Status", "Cluster", "Extra", "Hybrid", "Size", "Created", "Updated", "External ElasticSearch", "Slack Webhook"})
+// projectListTable returns the table of the given projects
+func projectListTable(projects []squarescale.Project) *ui.Table {
+	table := ui.NewTable("Name", "UUID", "Monitoring", "Provider", "Credentials", "Region", "Organization", "Status", "Cluster", "Extra", "Hybrid", "Size", "Created", "Updated", "External ElasticSearch", "Slack Webhook")
 
 	location, _ := time.LoadLocation(time.Now().Location().String())
 
@@ -99,7 +105,7 @@ func fmtProjectListOutput(projects []squarescale.Project, organizations []square
 		if project.HybridClusterEnabled {
 			isHybrid = "true"
 		}
-		table.Append([]string{
+		table.Append(
 			project.Name,
 			project.UUID,
 			monitoring,
@@ -116,43 +122,8 @@ func fmtProjectListOutput(projects []squarescale.Project, organizations []square
 			fmt.Sprintf("%s (%s)", project.UpdatedAt.In(location).Format("2006-01-02 15:04"), humantime.Since(project.UpdatedAt)),
 			project.ExternalES,
 			project.SlackWebHook,
-		})
+		)
 	}
 
-	for _, o := range organizations {
-		for _, project := range o.Projects {
-			monitoring := ""
-			if project.MonitoringEnabled && len(project.MonitoringEngine) > 0 {
-				monitoring = project.MonitoringEngine
-			}
-			isHybrid := "false"
-			if project.HybridClusterEnabled {
-				isHybrid = "true"
-			}
-			table.Append([]string{
-				project.Name,
-				project.UUID,
-				monitoring,
-				fmt.Sprintf("%s/%s", project.Provider, project.ProviderLabel),
-				project.Credentials,
-				fmt.Sprintf("%s/%s", project.Region, project.RegionLabel),
-				project.Organization,
-				project.ProjectStatus(),
-				project.ProjectStateLessCount(),
-				project.ProjectStateFulCount(),
-				isHybrid,
-				project.NodeSize,
-				fmt.Sprintf("%s (%s)", project.CreatedAt.In(location).Format("2006-01-02 15:04"), humantime.Since(project.CreatedAt)),
-				fmt.Sprintf("%s (%s)", project.UpdatedAt.In(location).Format("2006-01-02 15:04"), humantime.Since(project.UpdatedAt)),
-				project.ExternalES,
-				project.SlackWebHook,
-			})
-		}
-	}
-
-	ui.FormatTable(table)
-
-	table.Render()
-	// Remove trailing \n and HT
-	return string(regexp.MustCompile(`[\n\x09][\n\x09]*$`).ReplaceAll([]byte(tableString.String()), []byte("")))
+	return table
 }
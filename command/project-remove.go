@@ -5,7 +5,6 @@ import (
 	"flag"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/squarescale/squarescale-cli/squarescale"
 )
@@ -21,10 +20,11 @@ func (cmd *ProjectRemoveCommand) Run(args []string) int {
 	// Parse flags
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
 	alwaysYes := yesFlag(cmd.flagSet)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := explicitProjectNameFlag(cmd.flagSet)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
@@ -70,24 +70,13 @@ func (cmd *ProjectRemoveCommand) Run(args []string) int {
 			return "", err
 		}
 
-		var infraStatus string = "provisionning"
-
-	loop:
-		for {
-			status, err := client.GetProject(UUID)
-			if err != nil {
-				return "", err
-			}
-			if status.InfraStatus == "error" {
-				return "", fmt.Errorf("Unknown infrastructure error.")
-			} else if status.InfraStatus == "no_infra" {
-				break loop
-			} else if infraStatus != status.InfraStatus {
-				infraStatus = status.InfraStatus
-				cmd.Ui.Info("Infrastructure status: " + infraStatus)
-			}
-
-			time.Sleep(time.Second)
+		opts := cmd.waitOptions("unprovision project", *waitTimeout)
+		opts.Progress = func(infraStatus string) {
+			cmd.Ui.Info("Infrastructure status: " + infraStatus)
+		}
+		_, err = client.WaitProjectStatus(UUID, []string{"no_infra"}, opts)
+		if err != nil {
+			return "", err
 		}
 
 		return "", nil
@@ -106,13 +95,10 @@ func (cmd *ProjectRemoveCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
+		opts := cmd.waitOptions("wait for project remove", *waitTimeout)
 		res = cmd.runWithSpinner("wait for project remove", endpoint.String(), func(client *squarescale.Client) (string, error) {
-			projectStatus, err := client.WaitProject(UUID, 5)
-			if err != nil {
-				return projectStatus, err
-			} else {
-				return projectStatus, nil
-			}
+			err := client.WaitProjectDeleted(UUID, opts)
+			return "", err
 		})
 	}
 
@@ -130,6 +116,9 @@ func (cmd *ProjectRemoveCommand) Help() string {
 usage: sqsc project remove [options] <project_name>
 
   Destroy infrastructure and remove project completely.
+
+  The project must be given with -project-name or -project-uuid, the default
+  project of the context or of .sqsc.yaml is not used.
 `
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
@@ -16,9 +16,9 @@ type ProjectSettingsCommand struct {
 
 func (cmd *ProjectSettingsCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	hybridCluster := projectHybridClusterFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
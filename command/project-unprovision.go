@@ -18,9 +18,9 @@ type ProjectUnprovisionCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ProjectUnprovisionCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := explicitProjectNameFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -66,6 +66,9 @@ func (cmd *ProjectUnprovisionCommand) Help() string {
 usage: sqsc project unprovision [options]
 
   Unprovision infrasctructure of project.
+
+  The project must be given with -project-name or -project-uuid, the default
+  project of the context or of .sqsc.yaml is not used.
 `
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
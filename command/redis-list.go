@@ -18,9 +18,9 @@ type RedisListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *RedisListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -52,6 +52,10 @@ func (cmd *RedisListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(redisList)
+		}
+
 		var msg string = "Name\n"
 		for _, r := range redisList {
 			msg += fmt.Sprintf("%s\n", r.Name)
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/ui"
 )
 
 // SchedulingGroupListCommand is a cli.Command implementation for listing scheduling groups.
@@ -18,14 +19,22 @@ type SchedulingGroupListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *SchedulingGroupListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	output := listOutputFlag(cmd.flagSet, cmd.output)
+	columns := columnsFlag(cmd.flagSet)
+	sortBy := sortByFlag(cmd.flagSet)
+	noHeaders := noHeadersFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
 
+	if err := cmd.SetOutput(*output); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
 	if *projectUUID == "" && *projectName == "" {
 		return cmd.errorWithUsage(errors.New("Project name or uuid is mandatory"))
 	}
@@ -51,24 +60,20 @@ func (cmd *SchedulingGroupListCommand) Run(args []string) int {
 			return "", err
 		}
 
-		var msg string
+		if cmd.rawOutput() {
+			return cmd.formatRaw(schedulingGroups)
+		}
+
+		table := ui.NewTable("Name", "Nodes", "Services")
 		for _, sg := range schedulingGroups {
-			msg += fmt.Sprintf("[%s]\n", sg.Name)
-			msg += fmt.Sprintf("  Nodes:\n")
-			if client.GetSchedulingGroupNodes(sg, "\n\t") != "" {
-				msg += fmt.Sprintf("\t%s\n\n", client.GetSchedulingGroupNodes(sg, "\n\t"))
-			}
-			msg += fmt.Sprintf("  Services:\n")
-			if client.GetSchedulingGroupServices(sg, "\n\t") != "" {
-				msg += fmt.Sprintf("\t%s\n\n", client.GetSchedulingGroupServices(sg, "\n\t"))
-			}
+			table.Append(sg.Name, client.GetSchedulingGroupNodes(sg, "\n"), client.GetSchedulingGroupServices(sg, "\n"))
 		}
 
 		if len(schedulingGroups) == 0 {
-			msg = "No scheduling groups found"
+			return "No scheduling groups found", nil
 		}
 
-		return msg, nil
+		return cmd.renderTable(table, *columns, *sortBy, *noHeaders)
 	})
 }
 
@@ -23,9 +23,9 @@ func (cmd *ServiceAddCommand) Run(args []string) int {
 	username := dockerImageUsernameFlag(cmd.flagSet)
 	password := dockerImagePasswordFlag(cmd.flagSet)
 
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	serviceName := serviceFlag(cmd.flagSet)
 	runCommand := containerRunCmdFlag(cmd.flagSet)
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/ui"
 )
 
 // ServiceListCommand is a cli.Command implementation for listing all services aka Docker containers of project.
@@ -18,14 +19,23 @@ type ServiceListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ServiceListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	containerArg := serviceFlag(cmd.flagSet)
+	output := listOutputFlag(cmd.flagSet, cmd.output)
+	columns := columnsFlag(cmd.flagSet)
+	sortBy := sortByFlag(cmd.flagSet)
+	noHeaders := noHeadersFlag(cmd.flagSet)
+	reveal := revealFlag(cmd.flagSet)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
 
+	if err := cmd.SetOutput(*output); err != nil {
+		return cmd.errorWithUsage(err)
+	}
+
 	if cmd.flagSet.NArg() > 0 {
 		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
 	}
@@ -51,25 +61,29 @@ func (cmd *ServiceListCommand) Run(args []string) int {
 			return "", err
 		}
 
-		var msg string = "Name\tSize\tPort\tScheduling groups\n"
-		for _, c := range containers {
-			if *containerArg != "" && *containerArg != c.Name {
-				continue
+		if cmd.rawOutput() {
+			services := filterServices(containers, *containerArg)
+			if !*reveal {
+				services = redactServices(services)
 			}
+			return cmd.formatRaw(services)
+		}
 
+		table := ui.NewTable("Name", "Size", "Port", "Scheduling groups")
+		for _, c := range filterServices(containers, *containerArg) {
 			var schedulingGroups []string
 			for _, schedulingGroup := range c.SchedulingGroups {
 				schedulingGroups = append(schedulingGroups, schedulingGroup.Name)
 			}
 
-			msg += fmt.Sprintf("%s\t%d/%d\t%d\t%s\n", c.Name, c.Running, c.Size, c.WebPort, strings.Join(schedulingGroups[:], "\n\t\t\t"))
+			table.Append(c.Name, fmt.Sprintf("%d/%d", c.Running, c.Size), fmt.Sprintf("%d", c.WebPort), strings.Join(schedulingGroups, "\n"))
 		}
 
 		if len(containers) == 0 {
-			msg = "No service found"
+			return "No service found", nil
 		}
 
-		return cmd.FormatTable(msg, true), nil
+		return cmd.renderTable(table, *columns, *sortBy, *noHeaders)
 	})
 }
 
@@ -84,6 +98,9 @@ func (cmd *ServiceListCommand) Help() string {
 usage: sqsc service list [options]
 
   List services aka Docker containers of project.
+
 `
+	helpText += redactionHelp("With a raw output format, the values of the service variables", "reveal", true) + "\n"
+
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
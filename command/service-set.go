@@ -18,9 +18,9 @@ type ServiceSetCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ServiceSetCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	serviceName := serviceFlag(cmd.flagSet)
 	instances := containerInstancesFlag(cmd.flagSet)
 	runCommand := containerRunCmdFlag(cmd.flagSet)
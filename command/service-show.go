@@ -18,10 +18,11 @@ type ServiceShowCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *ServiceShowCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	containerArg := filterServiceFlag(cmd.flagSet)
+	reveal := revealFlag(cmd.flagSet)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
@@ -51,6 +52,14 @@ func (cmd *ServiceShowCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			services := filterServices(containers, *containerArg)
+			if !*reveal {
+				services = redactServices(services)
+			}
+			return cmd.formatRaw(services)
+		}
+
 		var msg string
 		found := false
 		for _, co := range containers {
@@ -104,6 +113,9 @@ func (cmd *ServiceShowCommand) Help() string {
 usage: sqsc service show [options]
 
   Show service aka Docker container of project.
+
 `
+	helpText += redactionHelp("With a raw output format, the values of the service variables", "reveal", true) + "\n"
+
 	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
 }
@@ -17,7 +17,7 @@ type StatusCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *StatusCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
 	}
@@ -18,15 +18,16 @@ type VolumeAddCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *VolumeAddCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	name := volumeNameFlag(cmd.flagSet)
 	size := volumeSizeFlag(cmd.flagSet)
 	volumeType := volumeTypeFlag(cmd.flagSet)
 	zone := volumeZoneFlag(cmd.flagSet)
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -65,8 +66,9 @@ func (cmd *VolumeAddCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
-		cmd.runWithSpinner("wait for volume add", endpoint.String(), func(client *squarescale.Client) (string, error) {
-			volume, err := client.WaitVolume(UUID, *name, 5)
+		opts := cmd.waitOptions("wait for volume add", *waitTimeout)
+		res = cmd.runWithSpinner("wait for volume add", endpoint.String(), func(client *squarescale.Client) (string, error) {
+			volume, err := client.WaitVolumeWithOptions(UUID, *name, opts)
 			if err != nil {
 				return "", err
 			} else {
@@ -75,7 +77,7 @@ func (cmd *VolumeAddCommand) Run(args []string) int {
 		})
 	}
 
-	return 0
+	return res
 }
 
 // Synopsis is part of cli.Command implementation.
@@ -20,10 +20,11 @@ func (cmd *VolumeDeleteCommand) Run(args []string) int {
 	// Parse flags
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
 	alwaysYes := yesFlag(cmd.flagSet)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 	nowait := nowaitFlag(cmd.flagSet)
+	waitTimeout := waitTimeoutFlag(cmd.flagSet)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -75,8 +76,9 @@ func (cmd *VolumeDeleteCommand) Run(args []string) int {
 	}
 
 	if !*nowait {
-		cmd.runWithSpinner("wait for volume delete", endpoint.String(), func(client *squarescale.Client) (string, error) {
-			_, err := client.WaitProject(UUID, 5)
+		opts := cmd.waitOptions("wait for volume delete", *waitTimeout)
+		res = cmd.runWithSpinner("wait for volume delete", endpoint.String(), func(client *squarescale.Client) (string, error) {
+			_, err := client.WaitProjectWithOptions(UUID, opts)
 			if err != nil {
 				return "", err
 			} else {
@@ -85,7 +87,7 @@ func (cmd *VolumeDeleteCommand) Run(args []string) int {
 		})
 	}
 
-	return 0
+	return res
 }
 
 // Synopsis is part of cli.Command implementation.
@@ -18,9 +18,9 @@ type VolumeListCommand struct {
 // Run is part of cli.Command implementation.
 func (cmd *VolumeListCommand) Run(args []string) int {
 	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
-	endpoint := endpointFlag(cmd.flagSet)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
 	projectUUID := projectUUIDFlag(cmd.flagSet)
-	projectName := projectNameFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
 
 	if err := cmd.flagSet.Parse(args); err != nil {
 		return 1
@@ -51,6 +51,10 @@ func (cmd *VolumeListCommand) Run(args []string) int {
 			return "", err
 		}
 
+		if cmd.rawOutput() {
+			return cmd.formatRaw(volumes)
+		}
+
 		//msg doit retourner la list des volumes valides : verifier si on recoit une liste des volumes ou des ID
 		//msg := fmt.Sprintf("list of availables volumes: %v", volume)
 
@@ -0,0 +1,167 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// WatchCommand is a cli.Command implementation for printing project events live.
+type WatchCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *WatchCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	projectUUID := projectUUIDFlag(cmd.flagSet)
+	projectName := projectNameFlag(cmd.flagSet, cmd.settings)
+	channel := watchChannelFlag(cmd.flagSet)
+
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	if *projectUUID == "" && *projectName == "" {
+		return cmd.errorWithUsage(errors.New("Project name or uuid is mandatory"))
+	}
+
+	channels := squarescale.EventChannels
+	if *channel != "" {
+		if !validEventChannel(*channel) {
+			return cmd.errorWithUsage(fmt.Errorf("Unknown channel: %v. Correct values are %s", *channel, strings.Join(squarescale.EventChannels, ", ")))
+		}
+		channels = []string{*channel}
+	}
+
+	client, err := cmd.ensureLogin(endpoint.String())
+	if err != nil {
+		return cmd.error(err)
+	}
+	defer client.Close()
+
+	var UUID string
+	if *projectUUID == "" {
+		UUID, err = client.ProjectByName(*projectName)
+		if err != nil {
+			return cmd.error(err)
+		}
+	} else {
+		UUID = *projectUUID
+	}
+
+	var subscriptions []*squarescale.Subscription
+	for _, name := range channels {
+		sub, err := client.Subscribe(UUID, name)
+		if err != nil {
+			return cmd.error(err)
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+
+	// merge all subscriptions into a single stream of events
+	events := make(chan squarescale.Event)
+	var wg sync.WaitGroup
+	for _, sub := range subscriptions {
+		wg.Add(1)
+		go func(sub *squarescale.Subscription) {
+			defer wg.Done()
+			for event := range sub.Events {
+				events <- event
+			}
+		}(sub)
+	}
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	for {
+		select {
+		case <-client.Context().Done():
+			return 0
+		case event, ok := <-events:
+			if !ok {
+				for _, sub := range subscriptions {
+					if sub.Err() != nil {
+						return cmd.error(sub.Err())
+					}
+				}
+				return 0
+			}
+			cmd.Ui.Output(fmtEvent(event))
+		}
+	}
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *WatchCommand) Synopsis() string {
+	return "Print project events live"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *WatchCommand) Help() string {
+	helpText := `
+usage: sqsc watch [options]
+
+  Print the events of a project as they happen: infrastructure status
+  changes, service scheduling updates and notifications.
+  Keep running until interrupted.
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
+
+// validEventChannel checks a channel name given on the command line.
+func validEventChannel(channel string) bool {
+	for _, name := range squarescale.EventChannels {
+		if name == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// fmtEvent renders an event as "<time> [<type>] <description>".
+func fmtEvent(event squarescale.Event) string {
+	timestamp := event.Timestamp
+	t, err := event.Time()
+	if err == nil {
+		timestamp = t.Local().Format("2006-01-02 15:04:05")
+	}
+
+	var description string
+	switch {
+	case event.InfraStatus != nil:
+		description = fmt.Sprintf("Infrastructure status: %s", event.InfraStatus.Status)
+		if event.InfraStatus.PreviousStatus != "" {
+			description = fmt.Sprintf("Infrastructure status: %s -> %s", event.InfraStatus.PreviousStatus, event.InfraStatus.Status)
+		}
+	case event.ServiceSchedule != nil:
+		description = fmt.Sprintf(
+			"Service %s: %s (%d/%d running)",
+			event.ServiceSchedule.Service, event.ServiceSchedule.Status,
+			event.ServiceSchedule.Running, event.ServiceSchedule.Desired,
+		)
+	case event.Notification != nil:
+		description = fmt.Sprintf("%s: %s", strings.ToUpper(event.Notification.Level), event.Notification.Message)
+	default:
+		description = string(event.Raw)
+	}
+
+	eventType := event.Type
+	if eventType == "" {
+		eventType = event.Channel
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s [%s] %s", timestamp, eventType, description))
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+	"github.com/squarescale/squarescale-cli/tokenstore"
+)
+
+// WhoamiCommand is a cli.Command implementation for displaying the authenticated user.
+type WhoamiCommand struct {
+	Meta
+	flagSet *flag.FlagSet
+}
+
+// whoami is the raw output of WhoamiCommand
+type whoami struct {
+	squarescale.User
+	Endpoint      string   `json:"endpoint"`
+	TokenSource   string   `json:"token_source"`
+	Organizations []string `json:"organizations"`
+}
+
+// Run is part of cli.Command implementation.
+func (cmd *WhoamiCommand) Run(args []string) int {
+	cmd.flagSet = newFlagSet(cmd, cmd.Ui)
+	endpoint := endpointFlag(cmd.flagSet, cmd.settings)
+	if err := cmd.flagSet.Parse(args); err != nil {
+		return 1
+	}
+
+	if cmd.flagSet.NArg() > 0 {
+		return cmd.errorWithUsage(fmt.Errorf("Unparsed arguments on the command line: %v", cmd.flagSet.Args()))
+	}
+
+	return cmd.runWithSpinner("fetch user", endpoint.String(), func(client *squarescale.Client) (string, error) {
+		_, source, err := tokenstore.LookupToken(cmd.settings.tokenKey(endpoint.String()))
+		if err != nil {
+			return "", err
+		}
+
+		organizations, err := client.ListOrganizations()
+		if err != nil {
+			return "", err
+		}
+
+		me := whoami{
+			User:          client.User(),
+			Endpoint:      endpoint.String(),
+			TokenSource:   source,
+			Organizations: []string{},
+		}
+		for _, o := range organizations {
+			me.Organizations = append(me.Organizations, o.Name)
+		}
+
+		if cmd.rawOutput() {
+			return cmd.formatRaw(me)
+		}
+
+		msg := fmt.Sprintf(
+			"Name:\t%s\nEmail:\t%s\nAdmin:\t%v\nEndpoint:\t%s\nToken source:\t%s\nOrganizations:\t%s",
+			me.FullName, me.Email, me.IsAdmin, me.Endpoint, me.TokenSource, strings.Join(me.Organizations, ", "),
+		)
+		return cmd.FormatTable(msg, false), nil
+	})
+}
+
+// Synopsis is part of cli.Command implementation.
+func (cmd *WhoamiCommand) Synopsis() string {
+	return "Display the authenticated user"
+}
+
+// Help is part of cli.Command implementation.
+func (cmd *WhoamiCommand) Help() string {
+	helpText := `
+usage: sqsc whoami [options]
+
+  Display the user the token belongs to, the endpoint, where the token comes
+  from (SQSC_TOKEN or the token store) and the organizations of the user.
+
+`
+	return strings.TrimSpace(helpText + optionsFromFlags(cmd.flagSet))
+}
@@ -13,6 +13,21 @@ func Commands(meta *command.Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"logout": func() (cli.Command, error) {
+			return &command.LogoutCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"logs": func() (cli.Command, error) {
+			return &command.LogsCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"apply": func() (cli.Command, error) {
+			return &command.ApplyCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"batch": func() (cli.Command, error) {
 			return &command.BatchCommand{}, nil
 		},
@@ -192,16 +207,41 @@ func Commands(meta *command.Meta) map[string]cli.CommandFactory {
 		"env": func() (cli.Command, error) {
 			return &command.EnvCommand{}, nil
 		},
+		"env diff": func() (cli.Command, error) {
+			return &command.EnvDiffCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"env export": func() (cli.Command, error) {
+			return &command.EnvExportCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"env get": func() (cli.Command, error) {
 			return &command.EnvGetCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"env import": func() (cli.Command, error) {
+			return &command.EnvImportCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"env promote": func() (cli.Command, error) {
+			return &command.EnvPromoteCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"env set": func() (cli.Command, error) {
 			return &command.EnvSetCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"plan": func() (cli.Command, error) {
+			return &command.PlanCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"project": func() (cli.Command, error) {
 			return &command.ProjectCommand{}, nil
 		},
@@ -240,11 +280,49 @@ func Commands(meta *command.Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"project clone": func() (cli.Command, error) {
+			return &command.ProjectCloneCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"project export": func() (cli.Command, error) {
+			return &command.ProjectExportCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"project import": func() (cli.Command, error) {
+			return &command.ProjectImportCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"project settings": func() (cli.Command, error) {
 			return &command.ProjectSettingsCommand{
 				Meta: *meta,
 			}, nil
 		},
+		"context": func() (cli.Command, error) {
+			return &command.ContextCommand{}, nil
+		},
+		"context add": func() (cli.Command, error) {
+			return &command.ContextAddCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"context delete": func() (cli.Command, error) {
+			return &command.ContextDeleteCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"context list": func() (cli.Command, error) {
+			return &command.ContextListCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"context use": func() (cli.Command, error) {
+			return &command.ContextUseCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"db": func() (cli.Command, error) {
 			return &command.DBCommand{}, nil
 		},
@@ -304,6 +382,16 @@ func Commands(meta *command.Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"watch": func() (cli.Command, error) {
+			return &command.WatchCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"whoami": func() (cli.Command, error) {
+			return &command.WhoamiCommand{
+				Meta: *meta,
+			}, nil
+		},
 		"version": func() (cli.Command, error) {
 			return &command.VersionCommand{
 				Meta:     *meta,
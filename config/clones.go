@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ClonesPath returns the path of the file recording the clones in progress,
+// next to the configuration file
+func ClonesPath() string {
+	return filepath.Join(filepath.Dir(Path()), "clones.yaml")
+}
+
+// CloneSource returns the UUID of the project the project of the given UUID
+// is being cloned from, empty when it is not a clone in progress
+func CloneSource(projectUUID string) (string, error) {
+	clones, err := loadClones()
+	if err != nil {
+		return "", err
+	}
+	return clones[projectUUID], nil
+}
+
+// StartClone records that the project of the given UUID is being cloned from
+// the source one, so that an interrupted clone can be resumed
+func StartClone(projectUUID, sourceUUID string) error {
+	clones, err := loadClones()
+	if err != nil {
+		return err
+	}
+	clones[projectUUID] = sourceUUID
+	return saveClones(clones)
+}
+
+// FinishClone forgets the clone of the project of the given UUID
+func FinishClone(projectUUID string) error {
+	clones, err := loadClones()
+	if err != nil {
+		return err
+	}
+	if _, ok := clones[projectUUID]; !ok {
+		return nil
+	}
+	delete(clones, projectUUID)
+	return saveClones(clones)
+}
+
+// loadClones reads the clones in progress, by UUID of the new project
+func loadClones() (map[string]string, error) {
+	clones := map[string]string{}
+
+	data, err := os.ReadFile(ClonesPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return clones, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, &clones); err != nil {
+		return nil, fmt.Errorf("Invalid clones file %s: %s", ClonesPath(), err)
+	}
+	if clones == nil {
+		clones = map[string]string{}
+	}
+	return clones, nil
+}
+
+func saveClones(clones map[string]string) error {
+	path := ClonesPath()
+	if len(clones) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(clones)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
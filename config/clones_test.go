@@ -0,0 +1,50 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/config"
+)
+
+func TestClones(t *testing.T) {
+	// given
+	t.Setenv(config.PathEnv, filepath.Join(t.TempDir(), "sqsc", "config.yaml"))
+
+	// when
+	err := config.StartClone("new-uuid", "source-uuid")
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	if source, err := config.CloneSource("new-uuid"); err != nil || source != "source-uuid" {
+		t.Errorf("Expect the source of the clone, got `%s`, %v", source, err)
+	}
+	if source, err := config.CloneSource("other-uuid"); err != nil || source != "" {
+		t.Errorf("Expect no source for another project, got `%s`, %v", source, err)
+	}
+
+	info, err := os.Stat(config.ClonesPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expect the file to be readable by the user only, got %s", info.Mode())
+	}
+
+	// when
+	err = config.FinishClone("new-uuid")
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	if source, _ := config.CloneSource("new-uuid"); source != "" {
+		t.Errorf("Expect the clone to be forgotten, got `%s`", source)
+	}
+	if _, err := os.Stat(config.ClonesPath()); !os.IsNotExist(err) {
+		t.Errorf("Expect the file to be removed once empty, got %v", err)
+	}
+}
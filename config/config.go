@@ -0,0 +1,167 @@
+// Package config reads and writes the configuration file of the CLI, which
+// holds the named contexts: endpoints along with the account and defaults
+// used with them.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PathEnv is the environment variable overriding the path of the
+// configuration file
+const PathEnv = "SQSC_CONFIG"
+
+// Config is the content of the configuration file
+type Config struct {
+	CurrentContext string    `yaml:"current-context,omitempty"`
+	Contexts       []Context `yaml:"contexts,omitempty"`
+	// CredentialStore is the name of the store of the tokens: netrc, file,
+	// keyring or the name of a credential helper
+	CredentialStore string `yaml:"credential-store,omitempty"`
+	// Defaults are the defaults of the global options and common flags
+	Defaults Defaults `yaml:"defaults,omitempty"`
+}
+
+// Context is a named endpoint along with the account and defaults used with
+// it
+type Context struct {
+	Name     string `yaml:"name" json:"name"`
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+	// Token is the key of the token in the token store, the endpoint by
+	// default, so that several accounts can be used on the same endpoint
+	Token        string `yaml:"token,omitempty" json:"token,omitempty"`
+	Organization string `yaml:"organization,omitempty" json:"organization,omitempty"`
+	Project      string `yaml:"project,omitempty" json:"project,omitempty"`
+}
+
+// TokenKey returns the key of the token of the context in the token store
+func (c *Context) TokenKey() string {
+	if c.Token != "" {
+		return c.Token
+	}
+	return c.Endpoint
+}
+
+// DefaultProject returns the name of the default project of the context,
+// prefixed by its organization if any, as expected by the API
+func (c *Context) DefaultProject() string {
+	return qualifiedProject(c.Organization, c.Project)
+}
+
+func qualifiedProject(organization, project string) string {
+	if project == "" || organization == "" || strings.Contains(project, "/") {
+		return project
+	}
+	return organization + "/" + project
+}
+
+// Path returns the path of the configuration file: $SQSC_CONFIG, or
+// sqsc/config.yaml in $XDG_CONFIG_HOME, ~/.config by default
+func Path() string {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		dir = filepath.Join(os.Getenv("HOME"), ".config")
+	}
+	return filepath.Join(dir, "sqsc", "config.yaml")
+}
+
+// Load reads the configuration file, an empty configuration being returned
+// when there is none
+func Load() (*Config, error) {
+	var c Config
+
+	data, err := os.ReadFile(Path())
+	if errors.Is(err, os.ErrNotExist) {
+		return &c, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("Invalid configuration file %s: %s", Path(), err)
+	}
+	return &c, nil
+}
+
+// Save writes the configuration file, readable by the user only
+func (c *Config) Save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	path := Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Context returns the context with the given name
+func (c *Config) Context(name string) (*Context, error) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("Context '%s' not found", name)
+}
+
+// Current returns the current context, nil if none is
+func (c *Config) Current() (*Context, error) {
+	if c.CurrentContext == "" {
+		return nil, nil
+	}
+	return c.Context(c.CurrentContext)
+}
+
+// SetContext adds the context, or replaces the one of the same name
+func (c *Config) SetContext(context Context) error {
+	if context.Name == "" {
+		return errors.New("Context name is mandatory")
+	}
+	if context.Endpoint == "" {
+		return errors.New("Context endpoint is mandatory")
+	}
+
+	if existing, err := c.Context(context.Name); err == nil {
+		*existing = context
+		return nil
+	}
+	c.Contexts = append(c.Contexts, context)
+	return nil
+}
+
+// UseContext makes the context with the given name the current one
+func (c *Config) UseContext(name string) error {
+	if _, err := c.Context(name); err != nil {
+		return err
+	}
+	c.CurrentContext = name
+	return nil
+}
+
+// DeleteContext deletes the context with the given name, which is no longer
+// the current one if it was
+func (c *Config) DeleteContext(name string) error {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			if c.CurrentContext == name {
+				c.CurrentContext = ""
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("Context '%s' not found", name)
+}
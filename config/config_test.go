@@ -0,0 +1,167 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/config"
+)
+
+func TestConfig(t *testing.T) {
+	t.Run("Test Path", testPath)
+	t.Run("Test Load without file", testLoadWithoutFile)
+	t.Run("Test Save and Load", testSaveAndLoad)
+	t.Run("Test SetContext", testSetContext)
+	t.Run("Test UseContext", testUseContext)
+	t.Run("Test DeleteContext", testDeleteContext)
+	t.Run("Test Context defaults", testContextDefaults)
+}
+
+func testPath(t *testing.T) {
+	// given
+	t.Setenv(config.PathEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+
+	// then
+	if path := config.Path(); path != "/xdg/sqsc/config.yaml" {
+		t.Errorf("Unexpected path `%s`", path)
+	}
+
+	// when
+	t.Setenv(config.PathEnv, "/custom.yaml")
+
+	// then
+	if path := config.Path(); path != "/custom.yaml" {
+		t.Errorf("Expect path from %s, got `%s`", config.PathEnv, path)
+	}
+}
+
+func testLoadWithoutFile(t *testing.T) {
+	// given
+	t.Setenv(config.PathEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	// when
+	c, err := config.Load()
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	if len(c.Contexts) != 0 || c.CurrentContext != "" {
+		t.Errorf("Expect an empty configuration, got %+v", c)
+	}
+}
+
+func testSaveAndLoad(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "sqsc", "config.yaml")
+	t.Setenv(config.PathEnv, path)
+	c := &config.Config{}
+	c.SetContext(config.Context{Name: "prod", Endpoint: "https://www.squarescale.io", Organization: "acme"})
+	c.UseContext("prod")
+
+	// when
+	err := c.Save()
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expect the file to be readable by the user only, got %s", info.Mode())
+	}
+
+	loaded, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	current, err := loaded.Current()
+	if err != nil || current.Organization != "acme" {
+		t.Errorf("Expect the current context to be loaded, got %+v, %v", current, err)
+	}
+}
+
+func testSetContext(t *testing.T) {
+	// given
+	c := &config.Config{}
+	c.SetContext(config.Context{Name: "prod", Endpoint: "https://a"})
+
+	// when
+	err := c.SetContext(config.Context{Name: "prod", Endpoint: "https://b"})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	if len(c.Contexts) != 1 || c.Contexts[0].Endpoint != "https://b" {
+		t.Errorf("Expect the context to be replaced, got %+v", c.Contexts)
+	}
+
+	if err := c.SetContext(config.Context{Name: "dev"}); err == nil || err.Error() != "Context endpoint is mandatory" {
+		t.Errorf("Expect an error on missing endpoint, got %v", err)
+	}
+}
+
+func testUseContext(t *testing.T) {
+	// given
+	c := &config.Config{}
+	c.SetContext(config.Context{Name: "prod", Endpoint: "https://a"})
+
+	// when
+	err := c.UseContext("dev")
+
+	// then
+	if err == nil || err.Error() != "Context 'dev' not found" {
+		t.Errorf("Expect an error on unknown context, got %v", err)
+	}
+	if c.CurrentContext != "" {
+		t.Errorf("Expect no current context, got `%s`", c.CurrentContext)
+	}
+}
+
+func testDeleteContext(t *testing.T) {
+	// given
+	c := &config.Config{}
+	c.SetContext(config.Context{Name: "prod", Endpoint: "https://a"})
+	c.SetContext(config.Context{Name: "dev", Endpoint: "https://b"})
+	c.UseContext("prod")
+
+	// when
+	err := c.DeleteContext("prod")
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	if len(c.Contexts) != 1 || c.Contexts[0].Name != "dev" {
+		t.Errorf("Expect only dev to remain, got %+v", c.Contexts)
+	}
+	if current, _ := c.Current(); current != nil {
+		t.Errorf("Expect no current context, got %+v", current)
+	}
+}
+
+func testContextDefaults(t *testing.T) {
+	for _, c := range []struct {
+		context           config.Context
+		tokenKey, project string
+	}{
+		{config.Context{Endpoint: "https://a"}, "https://a", ""},
+		{config.Context{Endpoint: "https://a", Token: "work", Project: "web"}, "work", "web"},
+		{config.Context{Endpoint: "https://a", Organization: "acme", Project: "web"}, "https://a", "acme/web"},
+		{config.Context{Endpoint: "https://a", Organization: "acme", Project: "other/web"}, "https://a", "other/web"},
+	} {
+		if key := c.context.TokenKey(); key != c.tokenKey {
+			t.Errorf("Expect token key `%s`, got `%s`", c.tokenKey, key)
+		}
+		if project := c.context.DefaultProject(); project != c.project {
+			t.Errorf("Expect default project `%s`, got `%s`", c.project, project)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocalFile is the name of the per-directory file of defaults, looked up in
+// the working directory and its parents
+const LocalFile = ".sqsc.yaml"
+
+// Defaults are the defaults of the global options and common flags, given by
+// the configuration file or by the per-directory file. Command line flags and
+// environment variables take precedence over them.
+type Defaults struct {
+	Color        *bool  `yaml:"color,omitempty"`
+	Format       *bool  `yaml:"format,omitempty"`
+	Progress     *bool  `yaml:"progress,omitempty"`
+	Output       string `yaml:"output,omitempty"`
+	Context      string `yaml:"context,omitempty"`
+	Endpoint     string `yaml:"endpoint,omitempty"`
+	Organization string `yaml:"organization,omitempty"`
+	Project      string `yaml:"project,omitempty"`
+}
+
+// DefaultProject returns the name of the default project, prefixed by the
+// default organization if any, as expected by the API
+func (d *Defaults) DefaultProject() string {
+	return qualifiedProject(d.Organization, d.Project)
+}
+
+// Merge overrides the defaults with the ones set in other
+func (d *Defaults) Merge(other Defaults) {
+	if other.Color != nil {
+		d.Color = other.Color
+	}
+	if other.Format != nil {
+		d.Format = other.Format
+	}
+	if other.Progress != nil {
+		d.Progress = other.Progress
+	}
+	for _, field := range []struct{ value, other *string }{
+		{&d.Output, &other.Output},
+		{&d.Context, &other.Context},
+		{&d.Endpoint, &other.Endpoint},
+		{&d.Organization, &other.Organization},
+		{&d.Project, &other.Project},
+	} {
+		if *field.other != "" {
+			*field.value = *field.other
+		}
+	}
+}
+
+// LocalPath returns the path of the per-directory file of defaults of the
+// working directory or of its closest parent, empty if there is none
+func LocalPath() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	for {
+		path := filepath.Join(dir, LocalFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadLocal reads the per-directory file of defaults, empty defaults being
+// returned when there is none. The endpoint cannot be set there, so that a
+// checked out repository cannot send tokens to another endpoint: contexts
+// are meant for that.
+func LoadLocal() (Defaults, error) {
+	var d Defaults
+
+	path := LocalPath()
+	if path == "" {
+		return d, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return d, nil
+	} else if err != nil {
+		return d, err
+	}
+
+	if err := yaml.Unmarshal(data, &d); err != nil {
+		return d, fmt.Errorf("Invalid file %s: %s", path, err)
+	}
+	if d.Endpoint != "" {
+		return d, fmt.Errorf("Invalid file %s: the endpoint cannot be set there, set a context instead", path)
+	}
+	return d, nil
+}
@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/config"
+)
+
+func TestDefaults(t *testing.T) {
+	t.Run("Test Merge", testMerge)
+	t.Run("Test Load with defaults", testLoadWithDefaults)
+	t.Run("Test LoadLocal in parent directory", testLoadLocalInParent)
+	t.Run("Test LoadLocal without file", testLoadLocalWithoutFile)
+	t.Run("Test LoadLocal with endpoint", testLoadLocalWithEndpoint)
+}
+
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func testMerge(t *testing.T) {
+	// given
+	yes, no := true, false
+	d := config.Defaults{Color: &yes, Format: &yes, Output: "json", Project: "web"}
+
+	// when
+	d.Merge(config.Defaults{Color: &no, Project: "api", Organization: "acme"})
+
+	// then
+	if *d.Color != false || *d.Format != true || d.Output != "json" || d.Project != "api" || d.Organization != "acme" {
+		t.Errorf("Unexpected merged defaults %+v", d)
+	}
+	if project := d.DefaultProject(); project != "acme/api" {
+		t.Errorf("Expect default project `acme/api`, got `%s`", project)
+	}
+}
+
+func testLoadWithDefaults(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("defaults:\n  progress: false\n  endpoint: https://www.example.com\n  project: web\n"), 0600)
+	t.Setenv(config.PathEnv, path)
+
+	// when
+	c, err := config.Load()
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	d := c.Defaults
+	if d.Progress == nil || *d.Progress || d.Color != nil || d.Endpoint != "https://www.example.com" || d.Project != "web" {
+		t.Errorf("Unexpected defaults %+v", d)
+	}
+}
+
+func testLoadLocalInParent(t *testing.T) {
+	// given
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, config.LocalFile), []byte("organization: acme\nproject: web\ncontext: staging\n"), 0644)
+	sub := filepath.Join(root, "deploy", "k8s")
+	os.MkdirAll(sub, 0755)
+	chdir(t, sub)
+
+	// when
+	d, err := config.LoadLocal()
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	if d.DefaultProject() != "acme/web" || d.Context != "staging" {
+		t.Errorf("Unexpected defaults %+v", d)
+	}
+}
+
+func testLoadLocalWithoutFile(t *testing.T) {
+	// given
+	chdir(t, t.TempDir())
+
+	// when
+	d, err := config.LoadLocal()
+
+	// then
+	if err != nil || d != (config.Defaults{}) {
+		t.Errorf("Expect empty defaults, got %+v, %v", d, err)
+	}
+}
+
+func testLoadLocalWithEndpoint(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, config.LocalFile), []byte("endpoint: https://attacker.example.com\n"), 0644)
+	chdir(t, dir)
+
+	// when
+	_, err := config.LoadLocal()
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "endpoint cannot be set") {
+		t.Errorf("Expect the endpoint to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,344 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+func createProject(client *squarescale.Client, p *Plan) error {
+	m := p.Manifest
+	payload := squarescale.JSONObject{
+		"name":                   m.Project.Name,
+		"hybrid_cluster_enabled": m.Project.HybridCluster,
+		"provider":               m.Project.Provider,
+		"region":                 m.Project.Region,
+		"credential_name":        m.Project.Credential,
+		"infra_type":             strings.ReplaceAll(m.Project.InfraType, "-", "_"),
+		"node_size":              m.Project.NodeSize,
+		"desired_size":           m.Project.NodeCount,
+		"root_disk_size_gb":      m.Project.RootDiskSize,
+		"monitoring":             map[string]string{"engine": "", "enabled": "false"},
+	}
+	if m.Project.Organization != "" {
+		payload["organization_name"] = m.Project.Organization
+	}
+	if m.Project.Monitoring != "" {
+		payload["monitoring"] = map[string]string{"engine": m.Project.Monitoring, "enabled": "true"}
+	}
+	if m.Project.SlackWebhook != "" {
+		payload["slack_webhook"] = m.Project.SlackWebhook
+	}
+	if m.Project.ExternalElasticsearch != "" {
+		payload["external_elasticsearch"] = m.Project.ExternalElasticsearch
+	}
+	if db := m.Database; db != nil {
+		payload["databases"] = []map[string]interface{}{{
+			"engine":                db.Engine,
+			"size":                  db.Size,
+			"version":               db.Version,
+			"backup_enabled":        db.Backup,
+			"backup_retention_days": db.BackupRetention,
+		}}
+	}
+
+	project, err := client.CreateProject(&payload)
+	if err != nil {
+		return err
+	}
+	p.ProjectUUID = project.UUID
+	return nil
+}
+
+func updateProject(client *squarescale.Client, p *Plan) error {
+	desired := p.Manifest.Project
+	if err := client.ConfigProjectSettings(p.ProjectUUID, squarescale.Project{HybridClusterEnabled: desired.HybridCluster}); err != nil {
+		return err
+	}
+	_, err := client.ConfigCluster(p.ProjectUUID, &squarescale.ClusterConfig{Size: uint(desired.NodeCount)})
+	return err
+}
+
+func setDatabase(client *squarescale.Client, p *Plan) error {
+	db := p.Manifest.Database
+	payload := squarescale.JSONObject{
+		"database": map[string]interface{}{
+			"enabled":               true,
+			"engine":                db.Engine,
+			"size":                  db.Size,
+			"version":               db.Version,
+			"backup_enabled":        db.Backup,
+			"backup_retention_days": db.BackupRetention,
+		},
+	}
+	_, err := client.ConfigDB(p.ProjectUUID, &payload)
+	return err
+}
+
+func createRedisChange(r Redis) Change {
+	return Change{Action: Create, Kind: "redis", Name: r.Name, apply: func(client *squarescale.Client, p *Plan) error {
+		return client.AddRedis(p.ProjectUUID, r.Name)
+	}}
+}
+
+func createVolumeChange(v Volume) Change {
+	return Change{Action: Create, Kind: "volume", Name: v.Name, apply: func(client *squarescale.Client, p *Plan) error {
+		return client.AddVolume(p.ProjectUUID, v.Name, v.Size, v.Type, v.Zone)
+	}}
+}
+
+func createExtraNodeChange(n ExtraNode) Change {
+	return Change{Action: Create, Kind: "extra node", Name: n.Name, apply: func(client *squarescale.Client, p *Plan) error {
+		if _, err := client.AddExtraNode(p.ProjectUUID, n.Name, n.NodeType, n.Zone); err != nil {
+			return err
+		}
+		return bindVolumes(n.Name, n.Volumes)(client, p)
+	}}
+}
+
+func bindVolumes(node string, volumes []string) func(*squarescale.Client, *Plan) error {
+	return func(client *squarescale.Client, p *Plan) error {
+		for _, v := range volumes {
+			if err := client.BindVolumeOnExtraNode(p.ProjectUUID, node, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func createSchedulingGroupChange(g SchedulingGroup) Change {
+	return Change{Action: Create, Kind: "scheduling group", Name: g.Name, apply: func(client *squarescale.Client, p *Plan) error {
+		_, err := client.AddSchedulingGroup(p.ProjectUUID, g.Name)
+		return err
+	}}
+}
+
+func createServiceChange(s Service) Change {
+	return Change{Action: Create, Kind: "service", Name: s.Name, apply: func(client *squarescale.Client, p *Plan) error {
+		if s.Username == Redacted || s.Password == Redacted {
+			return errors.New("The credentials of its Docker image were redacted")
+		}
+		payload := squarescale.JSONObject{
+			"docker_image":          squarescale.DockerImage(s.Image, s.Username, s.Password),
+			"name":                  s.Name,
+			"auto_start":            *s.AutoStart,
+			"size":                  s.Instances,
+			"container":             squarescale.JSONObject{"mem": s.Memory, "cpu": s.CPU},
+			"max_client_disconnect": s.MaxClientDisconnect,
+			"docker_capabilities":   s.DockerCapabilities,
+		}
+		if s.Entrypoint != "" {
+			payload["entrypoint"] = s.Entrypoint
+		}
+		if s.RunCommand != "" {
+			payload["run_command"] = s.RunCommand
+		}
+		if len(s.Volumes) > 0 {
+			payload["volumes_to_bind"] = volumesToBind(s.Volumes)
+		}
+		if env := resolveRedacted(nil, s.Env); len(env) > 0 {
+			payload["custom_environment"] = serviceEnv(env)
+		}
+		if len(s.SchedulingGroups) > 0 {
+			groups, err := schedulingGroups(client, p.ProjectUUID, s.SchedulingGroups)
+			if err != nil {
+				return err
+			}
+			ids := make([]int, 0, len(groups))
+			for _, g := range groups {
+				ids = append(ids, g.ID)
+			}
+			payload["scheduling_groups"] = ids
+		}
+		return client.AddService(p.ProjectUUID, payload)
+	}}
+}
+
+func updateService(s Service) func(*squarescale.Client, *Plan) error {
+	return func(client *squarescale.Client, p *Plan) error {
+		service, err := client.GetServiceInfo(p.ProjectUUID, s.Name)
+		if err != nil {
+			return err
+		}
+
+		service.Size = s.Instances
+		if s.RunCommand != "" {
+			service.RunCommand = s.RunCommand
+		}
+		if s.Entrypoint != "" {
+			service.Entrypoint = s.Entrypoint
+		}
+		service.AutoStart = *s.AutoStart
+		service.Limits.Memory = s.Memory
+		service.Limits.CPU = s.CPU
+		service.MaxClientDisconnect = s.MaxClientDisconnect
+		service.DockerCapabilities = s.DockerCapabilities
+		if s.SchedulingGroups != nil {
+			service.SchedulingGroups, err = schedulingGroups(client, p.ProjectUUID, s.SchedulingGroups)
+			if err != nil {
+				return err
+			}
+		}
+		if s.Env != nil {
+			current := map[string]string{}
+			for _, e := range service.CustomEnv {
+				if !e.Predefined {
+					current[e.Key] = e.Value
+				}
+			}
+			service.CustomEnv = serviceEnv(resolveRedacted(current, s.Env))
+		}
+		return client.ConfigService(service)
+	}
+}
+
+func createNetworkRuleChange(service string, r NetworkRule) Change {
+	return Change{Action: Create, Kind: "network rule", Name: service + "/" + r.Name, apply: func(client *squarescale.Client, p *Plan) error {
+		return client.CreateNetworkRule(p.ProjectUUID, service, networkRule(r))
+	}}
+}
+
+// replaceNetworkRule deletes then creates the rule, which the API does not
+// allow to update
+func replaceNetworkRule(service string, r NetworkRule) func(*squarescale.Client, *Plan) error {
+	return func(client *squarescale.Client, p *Plan) error {
+		if err := client.DeleteNetworkRule(p.ProjectUUID, service, r.Name); err != nil {
+			return err
+		}
+		return client.CreateNetworkRule(p.ProjectUUID, service, networkRule(r))
+	}
+}
+
+func createBatchChange(b Batch) Change {
+	return Change{Action: Create, Kind: "batch", Name: b.Name, apply: func(client *squarescale.Client, p *Plan) error {
+		order := squarescale.BatchOrder{
+			BatchCommon: squarescale.BatchCommon{
+				Name:               b.Name,
+				Periodic:           b.Periodic,
+				CronExpression:     b.CronExpression,
+				TimeZoneName:       b.TimeZone,
+				Limits:             squarescale.BatchLimits{Memory: b.Memory, CPU: b.CPU},
+				RunCommand:         b.RunCommand,
+				Entrypoint:         b.Entrypoint,
+				DockerCapabilities: b.DockerCapabilities,
+			},
+			DockerImage: squarescale.DockerImageInfos{
+				Name:     b.Image,
+				Private:  b.Username != "" && b.Password != "",
+				Username: b.Username,
+				Password: b.Password,
+			},
+			Volumes: volumesToBind(b.Volumes),
+		}
+		if _, err := client.CreateBatch(p.ProjectUUID, order); err != nil {
+			return err
+		}
+		env := resolveRedacted(nil, b.Env)
+		if len(env) == 0 {
+			return nil
+		}
+
+		// the environment of a batch can only be set once it exists
+		batch, err := client.GetBatchesInfo(p.ProjectUUID, b.Name)
+		if err != nil {
+			return err
+		}
+		batch.CustomEnvironment = env
+		return client.ConfigBatch(batch, p.ProjectUUID)
+	}}
+}
+
+func updateBatch(b Batch) func(*squarescale.Client, *Plan) error {
+	return func(client *squarescale.Client, p *Plan) error {
+		batch, err := client.GetBatchesInfo(p.ProjectUUID, b.Name)
+		if err != nil {
+			return err
+		}
+
+		if b.RunCommand != "" {
+			batch.RunCommand = b.RunCommand
+		}
+		if b.Entrypoint != "" {
+			batch.Entrypoint = b.Entrypoint
+		}
+		batch.Limits.Memory = b.Memory
+		batch.Limits.CPU = b.CPU
+		batch.DockerCapabilities = b.DockerCapabilities
+		if b.Env != nil {
+			batch.CustomEnvironment = resolveRedacted(batch.CustomEnvironment, b.Env)
+		}
+		return client.ConfigBatch(batch, p.ProjectUUID)
+	}
+}
+
+// setEnv sets all the project variables of the manifest in a single commit
+func setEnv(client *squarescale.Client, p *Plan) error {
+	env, err := squarescale.NewEnvironment(client, p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	variables := resolveRedacted(env.Project.CustomVariables(), p.Manifest.Env)
+	for _, k := range sortedKeys(variables) {
+		env.Project.SetVariable(k, variables[k])
+	}
+	return env.CommitEnvironment(client, p.ProjectUUID)
+}
+
+// deployNetworkPolicy adds a version of the network policy and deploys it
+func deployNetworkPolicy(client *squarescale.Client, p *Plan) error {
+	np := p.Manifest.NetworkPolicy
+	rules, err := json.Marshal(np.Rules)
+	if err != nil {
+		return err
+	}
+
+	version, err := client.AddNetworkPolicy(p.ProjectUUID, np.Name, string(rules))
+	if err != nil {
+		return err
+	}
+	return client.DeployNetworkPolicy(p.ProjectUUID, version)
+}
+
+func schedulingGroups(client *squarescale.Client, projectUUID string, names []string) ([]squarescale.SchedulingGroup, error) {
+	groups := make([]squarescale.SchedulingGroup, 0, len(names))
+	for _, name := range names {
+		group, err := client.GetSchedulingGroupInfo(projectUUID, name)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func volumesToBind(bindings []VolumeBinding) []squarescale.VolumeToBind {
+	volumes := make([]squarescale.VolumeToBind, 0, len(bindings))
+	for _, b := range bindings {
+		volumes = append(volumes, squarescale.VolumeToBind{Name: b.Name, MountPoint: b.MountPoint, ReadOnly: b.ReadOnly})
+	}
+	return volumes
+}
+
+func serviceEnv(env map[string]string) []squarescale.ServiceEnv {
+	vars := make([]squarescale.ServiceEnv, 0, len(env))
+	for _, k := range sortedKeys(env) {
+		vars = append(vars, squarescale.ServiceEnv{Key: k, Value: env[k]})
+	}
+	return vars
+}
+
+func networkRule(r NetworkRule) squarescale.NetworkRule {
+	return squarescale.NetworkRule{
+		Name:             r.Name,
+		InternalPort:     r.InternalPort,
+		InternalProtocol: r.InternalProtocol,
+		ExternalPort:     r.ExternalPort,
+		ExternalProtocol: r.ExternalProtocol,
+		DomainExpression: r.DomainExpression,
+		PathPrefix:       r.PathPrefix,
+	}
+}
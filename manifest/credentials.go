@@ -0,0 +1,66 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// imageCredentialPrefix starts the names of the local environment variables
+// holding the credentials of private Docker images
+const imageCredentialPrefix = "SQSC_IMAGE_"
+
+// ImageCredentialVariable returns the name of the local environment variable
+// holding the username or password of the private Docker image of a service,
+// such as SQSC_IMAGE_PASSWORD_MY_WEB for the password of the service my-web
+func ImageCredentialVariable(service, field string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(field+"_"+service))
+	return imageCredentialPrefix + name
+}
+
+// imageCredentialReference returns the reference to the local environment
+// written on export in place of a credential of a private Docker image, which
+// the API never returns
+func imageCredentialReference(service, field string) string {
+	return "${env:" + ImageCredentialVariable(service, field) + "}"
+}
+
+// ResolveImageCredentials replaces the credentials of the Docker images of
+// the services and batches written as ${env:NAME} by the value of NAME in the
+// local environment, given by lookupEnv. All the missing variables are
+// reported at once.
+func (m *Manifest) ResolveImageCredentials(lookupEnv func(string) (string, bool)) error {
+	var missing []string
+	resolve := func(kind, name string, value *string) {
+		if !strings.HasPrefix(*value, "${env:") || !strings.HasSuffix(*value, "}") {
+			return
+		}
+		variable := strings.TrimSuffix(strings.TrimPrefix(*value, "${env:"), "}")
+		resolved, ok := lookupEnv(variable)
+		if !ok {
+			missing = append(missing, fmt.Sprintf("Missing credential of the Docker image of %s '%s': %s is not set", kind, name, variable))
+			return
+		}
+		*value = resolved
+	}
+
+	for i := range m.Services {
+		s := &m.Services[i]
+		resolve("service", s.Name, &s.Username)
+		resolve("service", s.Name, &s.Password)
+	}
+	for i := range m.Batches {
+		b := &m.Batches[i]
+		resolve("batch", b.Name, &b.Username)
+		resolve("batch", b.Name, &b.Password)
+	}
+	if len(missing) > 0 {
+		return errors.New(strings.Join(missing, "\n"))
+	}
+	return nil
+}
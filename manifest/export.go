@@ -0,0 +1,269 @@
+package manifest
+
+import (
+	"encoding/json"
+
+	"github.com/squarescale/squarescale-cli/redact"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// Export describes an existing project as a manifest. Secret values are
+// replaced by Redacted unless showSecrets is set.
+func Export(client *squarescale.Client, projectUUID string, showSecrets bool) (*Manifest, error) {
+	details, err := client.GetProjectDetails(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	e := exporter{client: client, uuid: projectUUID, showSecrets: showSecrets}
+	e.exportProject(&details.Project)
+
+	exporters := []func() error{
+		e.exportRedis,
+		e.exportVolumes,
+		e.exportSchedulingGroups,
+		e.exportServices,
+		e.exportBatches,
+		e.exportNetworkPolicy,
+		e.exportEnv,
+	}
+	for _, export := range exporters {
+		if err := export(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &e.m, nil
+}
+
+type exporter struct {
+	client      *squarescale.Client
+	uuid        string
+	showSecrets bool
+	m           Manifest
+}
+
+func (e *exporter) env(variables map[string]string) map[string]string {
+	if len(variables) == 0 {
+		return nil
+	}
+
+	env := make(map[string]string, len(variables))
+	for k, v := range variables {
+		if !e.showSecrets && redact.IsSecret(k) {
+			v = Redacted
+		}
+		env[k] = v
+	}
+	return env
+}
+
+func (e *exporter) exportProject(details *squarescale.ProjectDetails) {
+	infra := details.Infrastructure
+	infraType := "single-node"
+	if details.HighAvailability {
+		infraType = "high-availability"
+	}
+
+	e.m.Project = Project{
+		Name:                  details.Name,
+		Organization:          details.Organization,
+		Provider:              infra.CloudProvider,
+		Region:                infra.Region,
+		Credential:            infra.CredentialName,
+		InfraType:             infraType,
+		NodeSize:              infra.NodeSize,
+		NodeCount:             infra.Cluster.DesiredSize,
+		RootDiskSize:          infra.RootDiskSize,
+		Monitoring:            infra.MonitoringEngine,
+		HybridCluster:         details.HybridClusterEnabled,
+		SlackWebhook:          details.SlackWebHook,
+		ExternalElasticsearch: details.ExternalElasticSearch,
+	}
+
+	if db := infra.Database; db.Enabled {
+		e.m.Database = &Database{
+			Engine:          db.Engine,
+			Size:            db.Size,
+			Version:         db.Version,
+			Backup:          db.BackupEnabled,
+			BackupRetention: db.BackupRetention,
+		}
+	}
+}
+
+func (e *exporter) exportRedis() error {
+	redis, err := e.client.GetRedis(e.uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range redis {
+		e.m.Redis = append(e.m.Redis, Redis{Name: r.Name})
+	}
+	return nil
+}
+
+func (e *exporter) exportVolumes() error {
+	volumes, err := e.client.GetVolumes(e.uuid)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := e.client.GetExtraNodes(e.uuid)
+	if err != nil {
+		return err
+	}
+
+	bound := map[string][]string{}
+	for _, v := range volumes {
+		e.m.Volumes = append(e.m.Volumes, Volume{Name: v.Name, Size: v.Size, Type: v.Type, Zone: v.Zone})
+		if v.ExtraNodeName != "" {
+			bound[v.ExtraNodeName] = append(bound[v.ExtraNodeName], v.Name)
+		}
+	}
+
+	for _, n := range nodes {
+		e.m.ExtraNodes = append(e.m.ExtraNodes, ExtraNode{Name: n.Name, NodeType: n.NodeType, Zone: n.Zone, Volumes: bound[n.Name]})
+	}
+	return nil
+}
+
+func (e *exporter) exportSchedulingGroups() error {
+	groups, err := e.client.GetSchedulingGroups(e.uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		e.m.SchedulingGroups = append(e.m.SchedulingGroups, SchedulingGroup{Name: g.Name})
+	}
+	return nil
+}
+
+func (e *exporter) exportServices() error {
+	services, err := e.client.GetServices(e.uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range services {
+		autoStart := s.AutoStart
+		service := Service{
+			Name:                s.Name,
+			Image:               s.DockerImage.Name,
+			RunCommand:          s.RunCommand,
+			Entrypoint:          s.Entrypoint,
+			Instances:           s.Size,
+			AutoStart:           &autoStart,
+			Memory:              s.Limits.Memory,
+			CPU:                 s.Limits.CPU,
+			MaxClientDisconnect: s.MaxClientDisconnect,
+			DockerCapabilities:  dockerCapabilities(s.DockerCapabilities),
+			Volumes:             volumeBindings(s.Volumes),
+		}
+		if s.DockerImage.Private {
+			service.Username = imageCredentialReference(s.Name, "username")
+			service.Password = imageCredentialReference(s.Name, "password")
+		}
+		for _, g := range s.SchedulingGroups {
+			service.SchedulingGroups = append(service.SchedulingGroups, g.Name)
+		}
+
+		env := map[string]string{}
+		for _, v := range s.CustomEnv {
+			if !v.Predefined {
+				env[v.Key] = v.Value
+			}
+		}
+		service.Env = e.env(env)
+
+		rules, err := e.client.ListNetworkRules(e.uuid, s.Name)
+		if err != nil {
+			return err
+		}
+		for _, r := range rules {
+			service.NetworkRules = append(service.NetworkRules, NetworkRule{
+				Name:             r.Name,
+				InternalPort:     r.InternalPort,
+				InternalProtocol: r.InternalProtocol,
+				ExternalPort:     r.ExternalPort,
+				ExternalProtocol: r.ExternalProtocol,
+				DomainExpression: r.DomainExpression,
+				PathPrefix:       r.PathPrefix,
+			})
+		}
+
+		e.m.Services = append(e.m.Services, service)
+	}
+	return nil
+}
+
+func (e *exporter) exportBatches() error {
+	batches, err := e.client.GetBatches(e.uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range batches {
+		e.m.Batches = append(e.m.Batches, Batch{
+			Name:               b.BatchCommon.Name,
+			Image:              b.DockerImage.Name,
+			RunCommand:         b.RunCommand,
+			Entrypoint:         b.Entrypoint,
+			Periodic:           b.Periodic,
+			CronExpression:     b.CronExpression,
+			TimeZone:           b.TimeZoneName,
+			Memory:             b.Limits.Memory,
+			CPU:                b.Limits.CPU,
+			DockerCapabilities: dockerCapabilities(b.DockerCapabilities),
+			Volumes:            volumeBindings(b.Volumes),
+			Env:                e.env(b.CustomEnvironment),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) exportNetworkPolicy() error {
+	policy, err := e.client.GetNetworkPolicy(e.uuid, "")
+	if err != nil {
+		return err
+	}
+	if !policy.IsLoaded() {
+		return nil
+	}
+
+	var rules []interface{}
+	if err := json.Unmarshal([]byte(policy.Policies), &rules); err != nil {
+		return err
+	}
+	e.m.NetworkPolicy = &NetworkPolicy{Name: policy.Name, Rules: rules}
+	return nil
+}
+
+func (e *exporter) exportEnv() error {
+	env, err := squarescale.NewEnvironment(e.client, e.uuid)
+	if err != nil {
+		return err
+	}
+
+	e.m.Env = e.env(env.Project.CustomVariables())
+	return nil
+}
+
+// dockerCapabilities returns the capabilities of a service or a batch, none
+// being exported as NONE since an empty list stands for the default ones
+func dockerCapabilities(capabilities []string) []string {
+	if len(capabilities) == 0 {
+		return []string{NoDockerCapabilities}
+	}
+	return capabilities
+}
+
+func volumeBindings(volumes []squarescale.VolumeToBind) []VolumeBinding {
+	var bindings []VolumeBinding
+	for _, v := range volumes {
+		bindings = append(bindings, VolumeBinding{Name: v.Name, MountPoint: v.MountPoint, ReadOnly: v.ReadOnly})
+	}
+	return bindings
+}
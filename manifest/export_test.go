@@ -0,0 +1,183 @@
+package manifest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/manifest"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+var exportRoutes = routesWith(map[string]string{
+	"GET /project_info/project-uuid": `{"project": {
+		"name": "my-project", "organization_name": "", "high_availability": true,
+		"infra": {
+			"provider": "aws", "region": "eu-west-1", "provider_credential_name": "my-credential",
+			"node_size": "t3.medium", "root_disk_size_gb": 20,
+			"cluster": {"desired_size": 3},
+			"db": {"enabled": true, "engine": "postgres", "size": "small", "version": "14"}
+		}
+	}}`,
+	"GET /projects/project-uuid/volumes":         `[{"name": "data", "size": 10, "type": "gp2", "zone": "eu-west-1a", "statefull_node_name": "storage"}]`,
+	"GET /projects/project-uuid/statefull_nodes": `[{"name": "storage", "node_type": "t3.small", "zone": "eu-west-1a"}]`,
+	"GET /projects/project-uuid/services": `[{
+		"container_id": 1, "name": "web", "size": 2, "auto_start": true, "max_client_disconnect": 0,
+		"docker_image": {"name": "nginx"},
+		"limits": {"mem": 256, "cpu": 100},
+		"custom_environment": [{"key": "LOG_LEVEL", "value": "info"}, {"key": "API_TOKEN", "value": "t0k3n"}, {"key": "PORT", "value": "80", "predefined": true}]
+	}]`,
+	"GET /projects/project-uuid/services/web/service_network_rules": `[{"name": "http", "internal_port": 80, "internal_protocol": "http", "external_protocol": "http"}]`,
+	"GET /projects/project-uuid/network_policy":                     `{"name": "default", "version": "1", "policies": "[{\"from\": \"web\", \"to\": \"db\"}]"}`,
+	"GET /projects/project-uuid/environment":                        `{"project": {"default": {"DB_HOST": "db"}, "custom": {"MODE": "production", "DB_PASSWORD": "s3cr3t"}}, "per_service": {}}`,
+})
+
+func TestExport(t *testing.T) {
+	t.Run("Nominal case on Export", nominalCaseOnExport)
+	t.Run("Test secrets shown on Export", secretsShownOnExport)
+	t.Run("Test Export then NewPlan", exportThenNewPlan)
+	t.Run("Test private image credentials on Export", privateImageCredentialsOnExport)
+}
+
+func nominalCaseOnExport(t *testing.T) {
+	// given
+	api := newFakeAPI(exportRoutes)
+	defer api.Close()
+
+	// when
+	m, err := manifest.Export(squarescale.NewClient(api.URL, "some-token"), "project-uuid", false)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	data, err := m.Marshal()
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	for _, expected := range []string{
+		"credential: my-credential",
+		"infra-type: high-availability",
+		"engine: postgres",
+		"node-type: t3.small",
+		"image: nginx",
+		"internal-port: 80",
+		"from: web",
+		"MODE: production",
+		"DB_PASSWORD: <redacted>",
+		"API_TOKEN: <redacted>",
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expect export to contain `%s`, got\n%s", expected, data)
+		}
+	}
+
+	for _, secret := range []string{"s3cr3t", "t0k3n", "DB_HOST", "PORT"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expect export not to contain `%s`, got\n%s", secret, data)
+		}
+	}
+
+	expected := "env DB_PASSWORD, service web env API_TOKEN"
+	if strings.Join(m.RedactedValues(), ", ") != expected {
+		t.Errorf("Expect redacted values `%s`, got `%v`", expected, m.RedactedValues())
+	}
+
+	if _, err := manifest.Parse(data); err != nil {
+		t.Errorf("Expect the export to be a valid manifest, got `%s`", err)
+	}
+}
+
+func secretsShownOnExport(t *testing.T) {
+	// given
+	api := newFakeAPI(exportRoutes)
+	defer api.Close()
+
+	// when
+	m, err := manifest.Export(squarescale.NewClient(api.URL, "some-token"), "project-uuid", true)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if m.Env["DB_PASSWORD"] != "s3cr3t" {
+		t.Errorf("Expect DB_PASSWORD `%s`, got `%s`", "s3cr3t", m.Env["DB_PASSWORD"])
+	}
+
+	if len(m.RedactedValues()) != 0 {
+		t.Errorf("Expect no redacted values, got `%v`", m.RedactedValues())
+	}
+}
+
+func exportThenNewPlan(t *testing.T) {
+	// given
+	api := newFakeAPI(exportRoutes)
+	defer api.Close()
+	client := squarescale.NewClient(api.URL, "some-token")
+	exported, _ := manifest.Export(client, "project-uuid", false)
+	data, _ := exported.MarshalIndentJSON()
+	m, err := manifest.Parse(data)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// when
+	plan, err := manifest.NewPlan(client, m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if plan.HasChanges() {
+		t.Errorf("Expect no changes on the exported project, got\n%s", plan.String())
+	}
+}
+
+func privateImageCredentialsOnExport(t *testing.T) {
+	// given
+	routes := map[string]string{}
+	for k, v := range exportRoutes {
+		routes[k] = v
+	}
+	routes["GET /projects/project-uuid/services"] = `[{
+		"container_id": 1, "name": "my-web", "size": 1, "auto_start": true, "max_client_disconnect": 0,
+		"docker_image": {"name": "registry.example.com/web", "private": true},
+		"limits": {"mem": 256, "cpu": 100}
+	}]`
+	routes["GET /projects/project-uuid/services/my-web/service_network_rules"] = `[]`
+	api := newFakeAPI(routes)
+	defer api.Close()
+
+	m, err := manifest.Export(squarescale.NewClient(api.URL, "some-token"), "project-uuid", true)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+	if m.Services[0].Password != "${env:SQSC_IMAGE_PASSWORD_MY_WEB}" {
+		t.Fatalf("Expect a reference to the password, got `%s`", m.Services[0].Password)
+	}
+
+	// when
+	env := map[string]string{"SQSC_IMAGE_USERNAME_MY_WEB": "deploy"}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+	err = m.ResolveImageCredentials(lookupEnv)
+
+	// then
+	expected := "Missing credential of the Docker image of service 'my-web': SQSC_IMAGE_PASSWORD_MY_WEB is not set"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expect error `%s`, got `%v`", expected, err)
+	}
+
+	env["SQSC_IMAGE_PASSWORD_MY_WEB"] = "s3cr3t"
+	if err := m.ResolveImageCredentials(lookupEnv); err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+	if m.Services[0].Username != "deploy" || m.Services[0].Password != "s3cr3t" {
+		t.Errorf("Expect resolved credentials, got `%s` and `%s`", m.Services[0].Username, m.Services[0].Password)
+	}
+}
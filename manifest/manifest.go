@@ -0,0 +1,546 @@
+// Package manifest describes a whole SquareScale project in a YAML file and
+// computes the changes needed to bring an actual project to it.
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/squarescale/squarescale-cli/redact"
+	"gopkg.in/yaml.v3"
+)
+
+// Default values of the manifest, matching the ones of the CLI flags
+const (
+	DefaultInfraType    = "high-availability"
+	DefaultNodeCount    = 3
+	DefaultRootDiskSize = 20
+	DefaultInstances    = 1
+	DefaultMemory       = 256
+	DefaultCPU          = 100
+	DefaultVolumeSize   = 1
+	DefaultVolumeType   = "gp2"
+)
+
+// Redacted replaces the secret values in exported manifests. Such values are
+// left unchanged when the manifest is applied.
+const Redacted = redact.Redacted
+
+// NoDockerCapabilities is the only capability of services and batches
+// which have none
+const NoDockerCapabilities = "NONE"
+
+// DefaultDockerCapabilities are the capabilities granted to services and
+// batches which do not list theirs
+var DefaultDockerCapabilities = []string{
+	"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+	"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+}
+
+// Manifest describes a project and all its resources
+type Manifest struct {
+	Project          Project           `yaml:"project"`
+	Database         *Database         `yaml:"database,omitempty"`
+	Redis            []Redis           `yaml:"redis,omitempty"`
+	Volumes          []Volume          `yaml:"volumes,omitempty"`
+	ExtraNodes       []ExtraNode       `yaml:"extra-nodes,omitempty"`
+	SchedulingGroups []SchedulingGroup `yaml:"scheduling-groups,omitempty"`
+	Services         []Service         `yaml:"services,omitempty"`
+	Batches          []Batch           `yaml:"batches,omitempty"`
+	NetworkPolicy    *NetworkPolicy    `yaml:"network-policy,omitempty"`
+	Env              map[string]string `yaml:"env,omitempty"`
+}
+
+// Project describes the infrastructure of the project
+type Project struct {
+	Name                  string `yaml:"name"`
+	Organization          string `yaml:"organization,omitempty"`
+	Provider              string `yaml:"provider"`
+	Region                string `yaml:"region"`
+	Credential            string `yaml:"credential"`
+	InfraType             string `yaml:"infra-type,omitempty"`
+	NodeSize              string `yaml:"node-size"`
+	NodeCount             int    `yaml:"node-count,omitempty"`
+	RootDiskSize          int    `yaml:"root-disk-size,omitempty"`
+	Monitoring            string `yaml:"monitoring,omitempty"`
+	HybridCluster         bool   `yaml:"hybrid-cluster,omitempty"`
+	SlackWebhook          string `yaml:"slack-webhook,omitempty"`
+	ExternalElasticsearch string `yaml:"external-elasticsearch,omitempty"`
+}
+
+// Database describes the managed database of the project
+type Database struct {
+	Engine          string `yaml:"engine"`
+	Size            string `yaml:"size"`
+	Version         string `yaml:"version,omitempty"`
+	Backup          bool   `yaml:"backup,omitempty"`
+	BackupRetention int    `yaml:"backup-retention,omitempty"`
+}
+
+// Redis describes a managed Redis database
+type Redis struct {
+	Name string `yaml:"name"`
+}
+
+// Volume describes a volume of the project
+type Volume struct {
+	Name string `yaml:"name"`
+	Size int    `yaml:"size,omitempty"`
+	Type string `yaml:"type,omitempty"`
+	Zone string `yaml:"zone"`
+}
+
+// ExtraNode describes an extra node of the project and the volumes bound to it
+type ExtraNode struct {
+	Name     string   `yaml:"name"`
+	NodeType string   `yaml:"node-type"`
+	Zone     string   `yaml:"zone"`
+	Volumes  []string `yaml:"volumes,omitempty"`
+}
+
+// SchedulingGroup describes a scheduling group of the project
+type SchedulingGroup struct {
+	Name string `yaml:"name"`
+}
+
+// VolumeBinding describes a volume mounted in a service or a batch
+type VolumeBinding struct {
+	Name       string `yaml:"name"`
+	MountPoint string `yaml:"mount-point"`
+	ReadOnly   bool   `yaml:"read-only,omitempty"`
+}
+
+// NetworkRule describes a network rule of a service
+type NetworkRule struct {
+	Name             string `yaml:"name"`
+	InternalPort     int    `yaml:"internal-port"`
+	InternalProtocol string `yaml:"internal-protocol,omitempty"`
+	ExternalPort     int    `yaml:"external-port,omitempty"`
+	ExternalProtocol string `yaml:"external-protocol,omitempty"`
+	DomainExpression string `yaml:"domain-expression,omitempty"`
+	PathPrefix       string `yaml:"path-prefix,omitempty"`
+}
+
+// Service describes a service aka Docker container of the project
+type Service struct {
+	Name                string            `yaml:"name"`
+	Image               string            `yaml:"image"`
+	Username            string            `yaml:"username,omitempty"`
+	Password            string            `yaml:"password,omitempty"`
+	RunCommand          string            `yaml:"run-command,omitempty"`
+	Entrypoint          string            `yaml:"entrypoint,omitempty"`
+	Instances           int               `yaml:"instances,omitempty"`
+	AutoStart           *bool             `yaml:"auto-start,omitempty"`
+	Memory              int               `yaml:"memory,omitempty"`
+	CPU                 int               `yaml:"cpu,omitempty"`
+	MaxClientDisconnect string            `yaml:"max-client-disconnect,omitempty"`
+	SchedulingGroups    []string          `yaml:"scheduling-groups,omitempty"`
+	DockerCapabilities  []string          `yaml:"docker-capabilities,omitempty"`
+	Volumes             []VolumeBinding   `yaml:"volumes,omitempty"`
+	Env                 map[string]string `yaml:"env,omitempty"`
+	NetworkRules        []NetworkRule     `yaml:"network-rules,omitempty"`
+}
+
+// Batch describes a batch of the project
+type Batch struct {
+	Name               string            `yaml:"name"`
+	Image              string            `yaml:"image"`
+	Username           string            `yaml:"username,omitempty"`
+	Password           string            `yaml:"password,omitempty"`
+	RunCommand         string            `yaml:"run-command,omitempty"`
+	Entrypoint         string            `yaml:"entrypoint,omitempty"`
+	Periodic           bool              `yaml:"periodic,omitempty"`
+	CronExpression     string            `yaml:"cron-expression,omitempty"`
+	TimeZone           string            `yaml:"time-zone,omitempty"`
+	Memory             int               `yaml:"memory,omitempty"`
+	CPU                int               `yaml:"cpu,omitempty"`
+	DockerCapabilities []string          `yaml:"docker-capabilities,omitempty"`
+	Volumes            []VolumeBinding   `yaml:"volumes,omitempty"`
+	Env                map[string]string `yaml:"env,omitempty"`
+}
+
+// NetworkPolicy describes the network policy deployed on the project, its
+// rules being the ones of network-policy add
+type NetworkPolicy struct {
+	Name  string        `yaml:"name"`
+	Rules []interface{} `yaml:"rules"`
+}
+
+// Load reads the manifest file at path, "-" standing for the standard input
+func Load(path string) (*Manifest, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid manifest %s: %s", path, err)
+	}
+	return m, nil
+}
+
+// Parse decodes a YAML (or JSON) manifest, fills the default values and
+// validates it
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("Empty manifest")
+		}
+		return nil, err
+	}
+
+	m.setDefaults()
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Marshal encodes the manifest in YAML
+func (m *Manifest) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(m); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalIndentJSON encodes the manifest in JSON, with the keys of the YAML
+// document
+func (m *Manifest) MarshalIndentJSON() ([]byte, error) {
+	data, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FullName returns the name of the project as known by the API, prefixed by
+// its organization if any
+func (p Project) FullName() string {
+	if p.Organization != "" {
+		return p.Organization + "/" + p.Name
+	}
+	return p.Name
+}
+
+// ProjectOnly returns a manifest describing the project and its database
+// only, as created at once by the API
+func (m *Manifest) ProjectOnly() *Manifest {
+	return &Manifest{Project: m.Project, Database: m.Database}
+}
+
+// MoveToRegion sets the region of the project, moving the volumes and extra
+// nodes to the zones of the same name in the new region (eu-west-1a becomes
+// us-east-1a when moving from eu-west-1 to us-east-1)
+func (m *Manifest) MoveToRegion(region string) {
+	previous := m.Project.Region
+	m.Project.Region = region
+
+	zone := func(z string) string {
+		if previous != "" && strings.HasPrefix(z, previous) {
+			return region + strings.TrimPrefix(z, previous)
+		}
+		return z
+	}
+	for i := range m.Volumes {
+		m.Volumes[i].Zone = zone(m.Volumes[i].Zone)
+	}
+	for i := range m.ExtraNodes {
+		m.ExtraNodes[i].Zone = zone(m.ExtraNodes[i].Zone)
+	}
+}
+
+// RedactedValues lists the values of the manifest which were redacted on
+// export, as "env KEY", "service NAME env KEY" or "service NAME image credentials"
+func (m *Manifest) RedactedValues() []string {
+	var redacted []string
+	collect := func(prefix string, env map[string]string) {
+		for _, k := range sortedKeys(env) {
+			if env[k] == Redacted {
+				redacted = append(redacted, strings.TrimSpace(prefix+" env "+k))
+			}
+		}
+	}
+
+	collect("", m.Env)
+	for _, s := range m.Services {
+		if s.Username == Redacted || s.Password == Redacted {
+			redacted = append(redacted, "service "+s.Name+" image credentials")
+		}
+		collect("service "+s.Name, s.Env)
+	}
+	for _, b := range m.Batches {
+		collect("batch "+b.Name, b.Env)
+	}
+	return redacted
+}
+
+func (m *Manifest) setDefaults() {
+	if m.Project.InfraType == "" {
+		m.Project.InfraType = DefaultInfraType
+	}
+	if m.Project.NodeCount == 0 {
+		if m.Project.InfraType == "single-node" {
+			m.Project.NodeCount = 1
+		} else {
+			m.Project.NodeCount = DefaultNodeCount
+		}
+	}
+	if m.Project.RootDiskSize == 0 {
+		m.Project.RootDiskSize = DefaultRootDiskSize
+	}
+
+	for i := range m.Volumes {
+		v := &m.Volumes[i]
+		if v.Size == 0 {
+			v.Size = DefaultVolumeSize
+		}
+		if v.Type == "" {
+			v.Type = DefaultVolumeType
+		}
+	}
+
+	for i := range m.Services {
+		s := &m.Services[i]
+		if s.Instances == 0 {
+			s.Instances = DefaultInstances
+		}
+		if s.AutoStart == nil {
+			autoStart := true
+			s.AutoStart = &autoStart
+		}
+		if s.Memory == 0 {
+			s.Memory = DefaultMemory
+		}
+		if s.CPU == 0 {
+			s.CPU = DefaultCPU
+		}
+		if s.MaxClientDisconnect == "" {
+			s.MaxClientDisconnect = "0"
+		}
+		if s.DockerCapabilities == nil {
+			s.DockerCapabilities = append([]string(nil), DefaultDockerCapabilities...)
+		}
+		for j := range s.NetworkRules {
+			r := &s.NetworkRules[j]
+			if r.InternalProtocol == "" {
+				r.InternalProtocol = "http"
+			}
+			if r.ExternalProtocol == "" {
+				r.ExternalProtocol = "http"
+			}
+		}
+	}
+
+	for i := range m.Batches {
+		b := &m.Batches[i]
+		if b.Memory == 0 {
+			b.Memory = DefaultMemory
+		}
+		if b.CPU == 0 {
+			b.CPU = DefaultCPU
+		}
+		if b.DockerCapabilities == nil {
+			b.DockerCapabilities = append([]string(nil), DefaultDockerCapabilities...)
+		}
+	}
+}
+
+// Validate checks the consistency of the manifest, as the CLI commands do
+// for their flags
+func (m *Manifest) Validate() error {
+	p := m.Project
+	switch {
+	case p.Name == "":
+		return errors.New("Project name is mandatory")
+	case p.Provider == "":
+		return errors.New("Project provider is mandatory")
+	case p.Region == "":
+		return errors.New("Project region is mandatory")
+	case p.Credential == "":
+		return errors.New("Project credential is mandatory")
+	case p.NodeSize == "":
+		return errors.New("Project node size is mandatory")
+	}
+
+	switch p.InfraType {
+	case "high-availability":
+		if p.NodeCount < 3 || p.NodeCount%2 != 1 {
+			return fmt.Errorf("Project infrastructure type high-availability must have an odd number of nodes, at least 3 (%d)", p.NodeCount)
+		}
+	case "single-node":
+		if p.NodeCount != 1 {
+			return fmt.Errorf("Project infrastructure type single-node can not have more than 1 node (%d)", p.NodeCount)
+		}
+	default:
+		return fmt.Errorf("Unknown project infrastructure type: %s. Correct values are high-availability or single-node", p.InfraType)
+	}
+
+	if p.Monitoring != "" && p.Monitoring != "netdata" {
+		return fmt.Errorf("Unknown project monitoring engine: %s. Correct values are empty or netdata", p.Monitoring)
+	}
+
+	if db := m.Database; db != nil {
+		if db.Engine == "" || db.Size == "" {
+			return errors.New("Database engine and size are mandatory")
+		}
+		if db.Backup && db.BackupRetention <= 0 {
+			return errors.New("Database backup retention can only be strictly positive when backup is enabled")
+		}
+		if !db.Backup && db.BackupRetention != 0 {
+			return errors.New("Database backup retention can only be 0 when backup is disabled")
+		}
+	}
+
+	names := map[string]bool{}
+	unique := func(kind, name string) error {
+		if name == "" {
+			return fmt.Errorf("Name of %s is mandatory", kind)
+		}
+		if names[kind+"/"+name] {
+			return fmt.Errorf("Duplicate %s: %s", kind, name)
+		}
+		names[kind+"/"+name] = true
+		return nil
+	}
+
+	for _, r := range m.Redis {
+		if err := unique("redis", r.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range m.Volumes {
+		if err := unique("volume", v.Name); err != nil {
+			return err
+		}
+		if v.Zone == "" {
+			return fmt.Errorf("Volume '%s' zone is mandatory", v.Name)
+		}
+	}
+
+	for _, n := range m.ExtraNodes {
+		if err := unique("extra node", n.Name); err != nil {
+			return err
+		}
+		if n.NodeType == "" || n.Zone == "" {
+			return fmt.Errorf("Extra node '%s' node type and zone are mandatory", n.Name)
+		}
+		for _, v := range n.Volumes {
+			if !names["volume/"+v] {
+				return fmt.Errorf("Volume '%s' used by extra node '%s' is not declared", v, n.Name)
+			}
+		}
+	}
+
+	for _, g := range m.SchedulingGroups {
+		if err := unique("scheduling group", g.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range m.Services {
+		if err := unique("service", s.Name); err != nil {
+			return err
+		}
+		if s.Image == "" {
+			return fmt.Errorf("Service '%s' image is mandatory", s.Name)
+		}
+		for _, g := range s.SchedulingGroups {
+			if !names["scheduling group/"+g] {
+				return fmt.Errorf("Service '%s' uses undeclared scheduling group '%s'", s.Name, g)
+			}
+		}
+		if err := m.validateVolumeBindings("service", s.Name, s.Volumes); err != nil {
+			return err
+		}
+		rules := map[string]bool{}
+		for _, r := range s.NetworkRules {
+			if r.Name == "" {
+				return fmt.Errorf("Service '%s' network rule name is mandatory", s.Name)
+			}
+			if rules[r.Name] {
+				return fmt.Errorf("Service '%s' network rule '%s' is declared twice", s.Name, r.Name)
+			}
+			rules[r.Name] = true
+			if r.InternalPort <= 0 {
+				return fmt.Errorf("Service '%s' network rule '%s' internal port is mandatory", s.Name, r.Name)
+			}
+		}
+	}
+
+	for _, b := range m.Batches {
+		if err := unique("batch", b.Name); err != nil {
+			return err
+		}
+		if b.Image == "" {
+			return fmt.Errorf("Batch '%s' image is mandatory", b.Name)
+		}
+		if b.Periodic && (b.CronExpression == "" || b.TimeZone == "") {
+			return fmt.Errorf("Batch '%s' cron expression and time zone are mandatory when periodic", b.Name)
+		}
+		if _, err := time.LoadLocation(b.TimeZone); err != nil {
+			return fmt.Errorf("Batch '%s' time zone is not an IANA time zone name", b.Name)
+		}
+		if err := m.validateVolumeBindings("batch", b.Name, b.Volumes); err != nil {
+			return err
+		}
+	}
+
+	if np := m.NetworkPolicy; np != nil && np.Name == "" {
+		return errors.New("Network policy name is mandatory")
+	}
+
+	return nil
+}
+
+func (m *Manifest) validateVolumeBindings(kind, name string, bindings []VolumeBinding) error {
+	for _, b := range bindings {
+		if b.MountPoint == "" {
+			return fmt.Errorf("Mount point of volume '%s' is mandatory in %s '%s'", b.Name, kind, name)
+		}
+		found := false
+		for _, v := range m.Volumes {
+			found = found || v.Name == b.Name
+		}
+		if !found {
+			return fmt.Errorf("Volume '%s' used by %s '%s' is not declared", b.Name, kind, name)
+		}
+	}
+	return nil
+}
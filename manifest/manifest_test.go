@@ -0,0 +1,292 @@
+package manifest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/manifest"
+)
+
+const minimalManifest = `
+project:
+  name: my-project
+  provider: aws
+  region: eu-west-1
+  credential: my-credential
+  node-size: t3.medium
+`
+
+func TestManifest(t *testing.T) {
+	t.Run("Nominal case on Parse", nominalCaseOnParse)
+	t.Run("Test default values on Parse", defaultValuesOnParse)
+	t.Run("Test unknown field on Parse", unknownFieldOnParse)
+	t.Run("Test empty manifest on Parse", emptyManifestOnParse)
+	t.Run("Test invalid manifests on Parse", invalidManifestsOnParse)
+	t.Run("Test Marshal then Parse", marshalThenParse)
+	t.Run("Test ProjectOnly", testProjectOnly)
+	t.Run("Test MoveToRegion", testMoveToRegion)
+}
+
+func nominalCaseOnParse(t *testing.T) {
+	// given
+	data := minimalManifest + `
+database:
+  engine: postgres
+  size: small
+  backup: true
+  backup-retention: 7
+volumes:
+  - name: data
+    zone: eu-west-1a
+scheduling-groups:
+  - name: front
+services:
+  - name: web
+    image: nginx
+    instances: 2
+    scheduling-groups: [front]
+    volumes:
+      - name: data
+        mount-point: /data
+    network-rules:
+      - name: http
+        internal-port: 80
+env:
+  LOG_LEVEL: info
+`
+
+	// when
+	m, err := manifest.Parse([]byte(data))
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if m.Project.Name != "my-project" {
+		t.Errorf("Expect project name `%s`, got `%s`", "my-project", m.Project.Name)
+	}
+
+	if m.Database == nil || m.Database.BackupRetention != 7 {
+		t.Errorf("Expect database backup retention 7, got `%+v`", m.Database)
+	}
+
+	if len(m.Services) != 1 || m.Services[0].Instances != 2 {
+		t.Fatalf("Expect one service with 2 instances, got `%+v`", m.Services)
+	}
+
+	if m.Services[0].NetworkRules[0].InternalProtocol != "http" {
+		t.Errorf("Expect network rule internal protocol `http`, got `%s`", m.Services[0].NetworkRules[0].InternalProtocol)
+	}
+
+	if m.Env["LOG_LEVEL"] != "info" {
+		t.Errorf("Expect env LOG_LEVEL `info`, got `%s`", m.Env["LOG_LEVEL"])
+	}
+}
+
+func defaultValuesOnParse(t *testing.T) {
+	// given
+	data := minimalManifest + `
+volumes:
+  - name: data
+    zone: eu-west-1a
+services:
+  - name: web
+    image: nginx
+batches:
+  - name: job
+    image: busybox
+`
+
+	// when
+	m, err := manifest.Parse([]byte(data))
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if m.Project.InfraType != manifest.DefaultInfraType || m.Project.NodeCount != manifest.DefaultNodeCount || m.Project.RootDiskSize != manifest.DefaultRootDiskSize {
+		t.Errorf("Expect default project values, got `%+v`", m.Project)
+	}
+
+	if m.Volumes[0].Size != manifest.DefaultVolumeSize || m.Volumes[0].Type != manifest.DefaultVolumeType {
+		t.Errorf("Expect default volume values, got `%+v`", m.Volumes[0])
+	}
+
+	s := m.Services[0]
+	if s.Instances != manifest.DefaultInstances || !*s.AutoStart || s.Memory != manifest.DefaultMemory || s.CPU != manifest.DefaultCPU || s.MaxClientDisconnect != "0" {
+		t.Errorf("Expect default service values, got `%+v`", s)
+	}
+
+	if len(s.DockerCapabilities) != len(manifest.DefaultDockerCapabilities) {
+		t.Errorf("Expect default docker capabilities, got `%v`", s.DockerCapabilities)
+	}
+
+	default0 := manifest.DefaultDockerCapabilities[0]
+	s.DockerCapabilities[0] = "SYS_ADMIN"
+	if manifest.DefaultDockerCapabilities[0] != default0 {
+		t.Errorf("Expect a copy of the default docker capabilities, got `%v`", manifest.DefaultDockerCapabilities)
+	}
+
+	b := m.Batches[0]
+	if b.Memory != manifest.DefaultMemory || b.CPU != manifest.DefaultCPU {
+		t.Errorf("Expect default batch values, got `%+v`", b)
+	}
+}
+
+func unknownFieldOnParse(t *testing.T) {
+	// given
+	data := minimalManifest + "  node-sizes: t3.large\n"
+
+	// when
+	_, err := manifest.Parse([]byte(data))
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "field node-sizes not found") {
+		t.Fatalf("Expect unknown field error, got `%v`", err)
+	}
+}
+
+func emptyManifestOnParse(t *testing.T) {
+	// given
+	data := ""
+
+	// when
+	_, err := manifest.Parse([]byte(data))
+
+	// then
+	expectedError := "Empty manifest"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, err)
+	}
+}
+
+func invalidManifestsOnParse(t *testing.T) {
+	cases := []struct {
+		data          string
+		expectedError string
+	}{
+		{
+			data:          "project:\n  name: my-project\n",
+			expectedError: "Project provider is mandatory",
+		},
+		{
+			data:          minimalManifest + "  node-count: 2\n",
+			expectedError: "Project infrastructure type high-availability must have an odd number of nodes, at least 3 (2)",
+		},
+		{
+			data:          minimalManifest + "  infra-type: single-node\n  node-count: 3\n",
+			expectedError: "Project infrastructure type single-node can not have more than 1 node (3)",
+		},
+		{
+			data:          minimalManifest + "database:\n  engine: postgres\n  size: small\n  backup-retention: 7\n",
+			expectedError: "Database backup retention can only be 0 when backup is disabled",
+		},
+		{
+			data:          minimalManifest + "redis:\n  - name: cache\n  - name: cache\n",
+			expectedError: "Duplicate redis: cache",
+		},
+		{
+			data:          minimalManifest + "services:\n  - name: web\n    image: nginx\n    scheduling-groups: [front]\n",
+			expectedError: "Service 'web' uses undeclared scheduling group 'front'",
+		},
+		{
+			data:          minimalManifest + "services:\n  - name: web\n    image: nginx\n    volumes:\n      - name: data\n        mount-point: /data\n",
+			expectedError: "Volume 'data' used by service 'web' is not declared",
+		},
+		{
+			data:          minimalManifest + "batches:\n  - name: job\n    image: busybox\n    periodic: true\n",
+			expectedError: "Batch 'job' cron expression and time zone are mandatory when periodic",
+		},
+	}
+
+	for _, c := range cases {
+		// when
+		_, err := manifest.Parse([]byte(c.data))
+
+		// then
+		if err == nil || err.Error() != c.expectedError {
+			t.Errorf("Expect error `%s`, got `%v`", c.expectedError, err)
+		}
+	}
+}
+
+func marshalThenParse(t *testing.T) {
+	// given
+	m, err := manifest.Parse([]byte(minimalManifest + "env:\n  A: b\n"))
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// when
+	data, err := m.Marshal()
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+	parsed, err := manifest.Parse(data)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if parsed.Project != m.Project || parsed.Env["A"] != "b" {
+		t.Errorf("Expect the same manifest, got `%+v`", parsed)
+	}
+}
+
+func testProjectOnly(t *testing.T) {
+	// given
+	m, _ := manifest.Parse([]byte(minimalManifest + `
+database:
+  engine: postgres
+  size: small
+redis:
+  - name: cache
+env:
+  LOG_LEVEL: info
+`))
+
+	// when
+	project := m.ProjectOnly()
+
+	// then
+	if project.Project.Name != "my-project" || project.Database == nil {
+		t.Errorf("Expect the project and its database, got `%+v`", project)
+	}
+
+	if len(project.Redis) != 0 || len(project.Env) != 0 {
+		t.Errorf("Expect no other resource, got `%+v`", project)
+	}
+}
+
+func testMoveToRegion(t *testing.T) {
+	// given
+	m, _ := manifest.Parse([]byte(minimalManifest + `
+volumes:
+  - name: data
+    zone: eu-west-1a
+extra-nodes:
+  - name: storage
+    node-type: t3.small
+    zone: eu-west-1b
+    volumes: [data]
+`))
+
+	// when
+	m.MoveToRegion("us-east-1")
+
+	// then
+	if m.Project.Region != "us-east-1" {
+		t.Errorf("Expect region `%s`, got `%s`", "us-east-1", m.Project.Region)
+	}
+
+	if m.Volumes[0].Zone != "us-east-1a" {
+		t.Errorf("Expect volume zone `%s`, got `%s`", "us-east-1a", m.Volumes[0].Zone)
+	}
+
+	if m.ExtraNodes[0].Zone != "us-east-1b" {
+		t.Errorf("Expect extra node zone `%s`, got `%s`", "us-east-1b", m.ExtraNodes[0].Zone)
+	}
+}
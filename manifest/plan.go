@@ -0,0 +1,760 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// Action is what is done to a resource to bring it to its manifest
+type Action string
+
+// Actions of a plan. Delete is only reported: resources missing from the
+// manifest are never deleted by Apply. Replace reports the resources which
+// differ on settings that can not be changed once created, Apply refuses
+// the plans which have some.
+const (
+	Create  Action = "create"
+	Update  Action = "update"
+	Delete  Action = "delete"
+	Replace Action = "replace"
+)
+
+// Change describes a resource to create, update or delete
+type Change struct {
+	Action Action `json:"action"`
+	// Kind is the type of resource: project, database, service...
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Details lists the differences of an updated resource, one per field
+	Details []string `json:"details,omitempty"`
+
+	apply func(client *squarescale.Client, p *Plan) error
+}
+
+// String returns a one-line description of the change
+func (c Change) String() string {
+	symbol := "+"
+	switch c.Action {
+	case Update:
+		symbol = "~"
+	case Delete:
+		symbol = "-"
+	case Replace:
+		symbol = "-/+"
+	}
+	return fmt.Sprintf("%s %s %s", symbol, c.Kind, c.Name)
+}
+
+// Plan lists the changes bringing a project to its manifest, in the order
+// they are applied
+type Plan struct {
+	Manifest *Manifest
+	// ProjectUUID is empty until the project exists
+	ProjectUUID string
+	Changes     []Change
+}
+
+// String lists the changes of the plan with their details
+func (p *Plan) String() string {
+	lines := make([]string, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		lines = append(lines, change.String())
+		for _, detail := range change.Details {
+			lines = append(lines, "    "+detail)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HasChanges tells whether the project differs from its manifest
+func (p *Plan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// Pending returns the changes made by Apply, that is all but deletions
+func (p *Plan) Pending() []Change {
+	var pending []Change
+	for _, change := range p.Changes {
+		if change.apply != nil {
+			pending = append(pending, change)
+		}
+	}
+	return pending
+}
+
+// Applicable returns an error describing the replacements of the plan, nil
+// when Apply can make its changes
+func (p *Plan) Applicable() error {
+	var refused []string
+	for _, change := range p.Changes {
+		if change.Action == Replace {
+			refused = append(refused, fmt.Sprintf("Cannot change %s '%s' once created (%s)", change.Kind, change.Name, strings.Join(change.Details, ", ")))
+		}
+	}
+	if len(refused) > 0 {
+		return errors.New(strings.Join(refused, "\n"))
+	}
+	return nil
+}
+
+// Apply makes the pending changes of the plan in order and stops at the
+// first error. It returns the number of changes made, none when the plan is
+// not applicable.
+func (p *Plan) Apply(client *squarescale.Client) (int, error) {
+	if err := p.Applicable(); err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, change := range p.Pending() {
+		if err := change.apply(client, p); err != nil {
+			return applied, fmt.Errorf("Cannot %s %s '%s': %s", change.Action, change.Kind, change.Name, err)
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// NewPlan compares the project described by the manifest with its actual
+// state and returns the changes to apply, along with the resources missing
+// from the manifest and the ones to replace.
+func NewPlan(client *squarescale.Client, m *Manifest) (*Plan, error) {
+	p := &Plan{Manifest: m}
+
+	UUID, err := client.ProjectByName(m.Project.FullName())
+	if squarescale.IsNotFound(err) {
+		p.planNewProject()
+		return p, nil
+	} else if err != nil {
+		return nil, err
+	}
+	p.ProjectUUID = UUID
+
+	planners := []func(*squarescale.Client) error{
+		p.planProject,
+		p.planDatabase,
+		p.planRedis,
+		p.planVolumes,
+		p.planExtraNodes,
+		p.planSchedulingGroups,
+		p.planServices,
+		p.planBatches,
+		p.planNetworkPolicy,
+		p.planEnv,
+	}
+	for _, planner := range planners {
+		if err := planner(client); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Plan) add(change Change) {
+	p.Changes = append(p.Changes, change)
+}
+
+// addDeletions reports the existing resources of a kind missing from the
+// manifest
+func (p *Plan) addDeletions(kind string, existing []string, declared map[string]bool) {
+	for _, name := range existing {
+		if !declared[name] {
+			p.add(Change{Action: Delete, Kind: kind, Name: name})
+		}
+	}
+}
+
+// planNewProject creates everything, the database along with the project
+func (p *Plan) planNewProject() {
+	m := p.Manifest
+	p.add(Change{Action: Create, Kind: "project", Name: m.Project.Name, apply: createProject})
+	for _, r := range m.Redis {
+		p.add(createRedisChange(r))
+	}
+	for _, v := range m.Volumes {
+		p.add(createVolumeChange(v))
+	}
+	for _, n := range m.ExtraNodes {
+		p.add(createExtraNodeChange(n))
+	}
+	for _, g := range m.SchedulingGroups {
+		p.add(createSchedulingGroupChange(g))
+	}
+	for _, s := range m.Services {
+		p.add(createServiceChange(s))
+		for _, r := range s.NetworkRules {
+			p.add(createNetworkRuleChange(s.Name, r))
+		}
+	}
+	for _, b := range m.Batches {
+		p.add(createBatchChange(b))
+	}
+	if m.NetworkPolicy != nil {
+		p.add(Change{Action: Create, Kind: "network policy", Name: m.NetworkPolicy.Name, apply: deployNetworkPolicy})
+	}
+	if env := resolveRedacted(nil, m.Env); len(env) > 0 {
+		p.add(Change{Action: Create, Kind: "env", Name: "project", Details: envDetails(nil, env), apply: setEnv})
+	}
+}
+
+func (p *Plan) planProject(client *squarescale.Client) error {
+	desired := p.Manifest.Project
+	current, err := client.GetProject(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	var immutable []string
+	diff(&immutable, "provider", current.Provider, desired.Provider)
+	diff(&immutable, "region", current.Region, desired.Region)
+	diff(&immutable, "node-size", current.NodeSize, desired.NodeSize)
+	diff(&immutable, "infra-type", strings.ReplaceAll(current.InfraType, "_", "-"), desired.InfraType)
+	diff(&immutable, "root-disk-size", current.RootDiskSizeGB, desired.RootDiskSize)
+	diff(&immutable, "monitoring", current.MonitoringEngine, desired.Monitoring)
+	// the URLs may hold credentials, they are not shown
+	if current.SlackWebHook != desired.SlackWebhook {
+		immutable = append(immutable, "slack-webhook: changed")
+	}
+	if current.ExternalES != desired.ExternalElasticsearch {
+		immutable = append(immutable, "external-elasticsearch: changed")
+	}
+	if len(immutable) > 0 {
+		p.add(Change{Action: Replace, Kind: "project", Name: desired.Name, Details: immutable})
+		return nil
+	}
+
+	var details []string
+	diff(&details, "node-count", current.ClusterSize, desired.NodeCount)
+	diff(&details, "hybrid-cluster", current.HybridClusterEnabled, desired.HybridCluster)
+	if len(details) > 0 {
+		p.add(Change{Action: Update, Kind: "project", Name: desired.Name, Details: details, apply: updateProject})
+	}
+	return nil
+}
+
+func (p *Plan) planDatabase(client *squarescale.Client) error {
+	desired := p.Manifest.Database
+	current, err := client.GetDBConfig(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	if desired == nil {
+		if current.Enabled {
+			p.add(Change{Action: Delete, Kind: "database", Name: current.Engine})
+		}
+		return nil
+	}
+
+	var details []string
+	diff(&details, "enabled", current.Enabled, true)
+	diff(&details, "engine", current.Engine, desired.Engine)
+	diff(&details, "size", current.Size, desired.Size)
+	if desired.Version != "" {
+		diff(&details, "version", current.Version, desired.Version)
+	}
+	diff(&details, "backup", current.BackupEnabled, desired.Backup)
+	diff(&details, "backup-retention", current.BackupRetention, desired.BackupRetention)
+	if len(details) == 0 {
+		return nil
+	}
+
+	action := Update
+	if !current.Enabled {
+		action = Create
+	}
+	p.add(Change{Action: action, Kind: "database", Name: desired.Engine, Details: details, apply: setDatabase})
+	return nil
+}
+
+func (p *Plan) planRedis(client *squarescale.Client) error {
+	current, err := client.GetRedis(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	var names []string
+	for _, r := range current {
+		existing[r.Name] = true
+		names = append(names, r.Name)
+	}
+	declared := map[string]bool{}
+	for _, r := range p.Manifest.Redis {
+		declared[r.Name] = true
+		if !existing[r.Name] {
+			p.add(createRedisChange(r))
+		}
+	}
+	p.addDeletions("redis", names, declared)
+	return nil
+}
+
+func (p *Plan) planVolumes(client *squarescale.Client) error {
+	current, err := client.GetVolumes(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]squarescale.Volume{}
+	var names []string
+	for _, v := range current {
+		existing[v.Name] = v
+		names = append(names, v.Name)
+	}
+	declared := map[string]bool{}
+	for _, v := range p.Manifest.Volumes {
+		declared[v.Name] = true
+		c, ok := existing[v.Name]
+		if !ok {
+			p.add(createVolumeChange(v))
+			continue
+		}
+
+		var immutable []string
+		diff(&immutable, "size", c.Size, v.Size)
+		diff(&immutable, "type", c.Type, v.Type)
+		diff(&immutable, "zone", c.Zone, v.Zone)
+		if len(immutable) > 0 {
+			p.add(Change{Action: Replace, Kind: "volume", Name: v.Name, Details: immutable})
+		}
+	}
+	p.addDeletions("volume", names, declared)
+	return nil
+}
+
+func (p *Plan) planExtraNodes(client *squarescale.Client) error {
+	current, err := client.GetExtraNodes(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	volumes, err := client.GetVolumes(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+	bound := map[string]bool{}
+	for _, v := range volumes {
+		if v.ExtraNodeName != "" {
+			bound[v.ExtraNodeName+"/"+v.Name] = true
+		}
+	}
+
+	existing := map[string]squarescale.ExtraNode{}
+	var names []string
+	for _, n := range current {
+		existing[n.Name] = n
+		names = append(names, n.Name)
+	}
+	declared := map[string]bool{}
+	for _, n := range p.Manifest.ExtraNodes {
+		declared[n.Name] = true
+		c, ok := existing[n.Name]
+		if !ok {
+			p.add(createExtraNodeChange(n))
+			continue
+		}
+
+		var immutable []string
+		diff(&immutable, "node-type", c.NodeType, n.NodeType)
+		diff(&immutable, "zone", c.Zone, n.Zone)
+		if len(immutable) > 0 {
+			p.add(Change{Action: Replace, Kind: "extra node", Name: n.Name, Details: immutable})
+			continue
+		}
+
+		var unbound []string
+		var details []string
+		for _, v := range n.Volumes {
+			if !bound[n.Name+"/"+v] {
+				unbound = append(unbound, v)
+				details = append(details, fmt.Sprintf("volume %s: bound", v))
+			}
+		}
+		if len(unbound) > 0 {
+			p.add(Change{Action: Update, Kind: "extra node", Name: n.Name, Details: details, apply: bindVolumes(n.Name, unbound)})
+		}
+	}
+	p.addDeletions("extra node", names, declared)
+	return nil
+}
+
+func (p *Plan) planSchedulingGroups(client *squarescale.Client) error {
+	current, err := client.GetSchedulingGroups(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	var names []string
+	for _, g := range current {
+		existing[g.Name] = true
+		names = append(names, g.Name)
+	}
+	declared := map[string]bool{}
+	for _, g := range p.Manifest.SchedulingGroups {
+		declared[g.Name] = true
+		if !existing[g.Name] {
+			p.add(createSchedulingGroupChange(g))
+		}
+	}
+	p.addDeletions("scheduling group", names, declared)
+	return nil
+}
+
+func (p *Plan) planServices(client *squarescale.Client) error {
+	current, err := client.GetServices(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]squarescale.Service{}
+	var names []string
+	for _, s := range current {
+		existing[s.Name] = s
+		names = append(names, s.Name)
+	}
+	declared := map[string]bool{}
+	for _, s := range p.Manifest.Services {
+		declared[s.Name] = true
+		c, ok := existing[s.Name]
+		if !ok {
+			p.add(createServiceChange(s))
+			for _, r := range s.NetworkRules {
+				p.add(createNetworkRuleChange(s.Name, r))
+			}
+			continue
+		}
+
+		if immutable := imageDetails(c.DockerImage.Name, c.Volumes, s.Image, s.Volumes); len(immutable) > 0 {
+			p.add(Change{Action: Replace, Kind: "service", Name: s.Name, Details: immutable})
+		} else if details := serviceDetails(c, s); len(details) > 0 {
+			p.add(Change{Action: Update, Kind: "service", Name: s.Name, Details: details, apply: updateService(s)})
+		}
+
+		if err := p.planNetworkRules(client, s); err != nil {
+			return err
+		}
+	}
+	p.addDeletions("service", names, declared)
+	return nil
+}
+
+func serviceDetails(current squarescale.Service, desired Service) []string {
+	var details []string
+	diff(&details, "instances", current.Size, desired.Instances)
+	if desired.RunCommand != "" {
+		diff(&details, "run-command", current.RunCommand, desired.RunCommand)
+	}
+	if desired.Entrypoint != "" {
+		diff(&details, "entrypoint", current.Entrypoint, desired.Entrypoint)
+	}
+	diff(&details, "auto-start", current.AutoStart, *desired.AutoStart)
+	diff(&details, "memory", current.Limits.Memory, desired.Memory)
+	diff(&details, "cpu", current.Limits.CPU, desired.CPU)
+	if _, err := strconv.Atoi(desired.MaxClientDisconnect); err == nil {
+		diff(&details, "max-client-disconnect", current.MaxClientDisconnect, desired.MaxClientDisconnect)
+	}
+	diff(&details, "docker-capabilities", capabilities(current.DockerCapabilities), capabilities(desired.DockerCapabilities))
+
+	if desired.SchedulingGroups != nil {
+		var groups []string
+		for _, g := range current.SchedulingGroups {
+			groups = append(groups, g.Name)
+		}
+		diff(&details, "scheduling-groups", sortedList(groups), sortedList(desired.SchedulingGroups))
+	}
+
+	if desired.Env != nil {
+		env := map[string]string{}
+		for _, e := range current.CustomEnv {
+			if !e.Predefined {
+				env[e.Key] = e.Value
+			}
+		}
+		details = append(details, envDetails(env, resolveRedacted(env, desired.Env))...)
+	}
+	return details
+}
+
+// imageDetails compares the Docker image and the volume bindings of a service
+// or a batch, which the API does not allow to change once created
+func imageDetails(currentImage string, current []squarescale.VolumeToBind, desiredImage string, desired []VolumeBinding) []string {
+	var details []string
+	diff(&details, "image", currentImage, desiredImage)
+	if desired != nil {
+		diff(&details, "volumes", volumeList(volumeBindings(current)), volumeList(desired))
+	}
+	return details
+}
+
+// volumeList returns the sorted bindings as name:mount-point, suffixed by :ro
+// when read only
+func volumeList(bindings []VolumeBinding) []string {
+	values := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		value := b.Name + ":" + b.MountPoint
+		if b.ReadOnly {
+			value += ":ro"
+		}
+		values = append(values, value)
+	}
+	return sortedList(values)
+}
+
+func (p *Plan) planNetworkRules(client *squarescale.Client, s Service) error {
+	current, err := client.ListNetworkRules(p.ProjectUUID, s.Name)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]squarescale.NetworkRule{}
+	var names []string
+	for _, r := range current {
+		existing[r.Name] = r
+		names = append(names, s.Name+"/"+r.Name)
+	}
+	declared := map[string]bool{}
+	for _, r := range s.NetworkRules {
+		declared[s.Name+"/"+r.Name] = true
+		c, ok := existing[r.Name]
+		if !ok {
+			p.add(createNetworkRuleChange(s.Name, r))
+			continue
+		}
+
+		var details []string
+		diff(&details, "internal-port", c.InternalPort, r.InternalPort)
+		diff(&details, "internal-protocol", c.InternalProtocol, r.InternalProtocol)
+		if r.ExternalPort != 0 {
+			diff(&details, "external-port", c.ExternalPort, r.ExternalPort)
+		}
+		diff(&details, "external-protocol", c.ExternalProtocol, r.ExternalProtocol)
+		diff(&details, "domain-expression", c.DomainExpression, r.DomainExpression)
+		diff(&details, "path-prefix", c.PathPrefix, r.PathPrefix)
+		if len(details) > 0 {
+			change := createNetworkRuleChange(s.Name, r)
+			change.Action = Update
+			change.Details = details
+			change.apply = replaceNetworkRule(s.Name, r)
+			p.add(change)
+		}
+	}
+	p.addDeletions("network rule", names, declared)
+	return nil
+}
+
+func (p *Plan) planBatches(client *squarescale.Client) error {
+	current, err := client.GetBatches(p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]squarescale.RunningBatch{}
+	var names []string
+	for _, b := range current {
+		existing[b.BatchCommon.Name] = b
+		names = append(names, b.BatchCommon.Name)
+	}
+	declared := map[string]bool{}
+	for _, b := range p.Manifest.Batches {
+		declared[b.Name] = true
+		c, ok := existing[b.Name]
+		if !ok {
+			p.add(createBatchChange(b))
+			continue
+		}
+
+		if immutable := imageDetails(c.DockerImage.Name, c.Volumes, b.Image, b.Volumes); len(immutable) > 0 {
+			p.add(Change{Action: Replace, Kind: "batch", Name: b.Name, Details: immutable})
+			continue
+		}
+
+		var details []string
+		if b.RunCommand != "" {
+			diff(&details, "run-command", c.RunCommand, b.RunCommand)
+		}
+		if b.Entrypoint != "" {
+			diff(&details, "entrypoint", c.Entrypoint, b.Entrypoint)
+		}
+		diff(&details, "memory", c.Limits.Memory, b.Memory)
+		diff(&details, "cpu", c.Limits.CPU, b.CPU)
+		diff(&details, "docker-capabilities", capabilities(c.DockerCapabilities), capabilities(b.DockerCapabilities))
+		if b.Env != nil {
+			details = append(details, envDetails(c.CustomEnvironment, resolveRedacted(c.CustomEnvironment, b.Env))...)
+		}
+		if len(details) > 0 {
+			p.add(Change{Action: Update, Kind: "batch", Name: b.Name, Details: details, apply: updateBatch(b)})
+		}
+	}
+	p.addDeletions("batch", names, declared)
+	return nil
+}
+
+func (p *Plan) planNetworkPolicy(client *squarescale.Client) error {
+	current, err := client.GetNetworkPolicy(p.ProjectUUID, "")
+	if err != nil {
+		return err
+	}
+
+	desired := p.Manifest.NetworkPolicy
+	if desired == nil {
+		if current.IsLoaded() {
+			p.add(Change{Action: Delete, Kind: "network policy", Name: current.Name})
+		}
+		return nil
+	}
+
+	if !current.IsLoaded() {
+		p.add(Change{Action: Create, Kind: "network policy", Name: desired.Name, apply: deployNetworkPolicy})
+		return nil
+	}
+
+	var details []string
+	diff(&details, "name", current.Name, desired.Name)
+	same, err := sameRules(current.Policies, desired.Rules)
+	if err != nil {
+		return err
+	}
+	if !same {
+		details = append(details, "rules: changed")
+	}
+	if len(details) > 0 {
+		p.add(Change{Action: Update, Kind: "network policy", Name: desired.Name, Details: details, apply: deployNetworkPolicy})
+	}
+	return nil
+}
+
+// sameRules compares the JSON rules of a network policy with the ones of the
+// manifest, regardless of their formatting
+func sameRules(policies string, rules []interface{}) (bool, error) {
+	var current interface{}
+	if err := json.Unmarshal([]byte(policies), &current); err != nil {
+		return false, err
+	}
+	c, err := json.Marshal(current)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return false, err
+	}
+	var desired interface{}
+	if err := json.Unmarshal(data, &desired); err != nil {
+		return false, err
+	}
+	d, err := json.Marshal(desired)
+	if err != nil {
+		return false, err
+	}
+
+	return string(c) == string(d), nil
+}
+
+func (p *Plan) planEnv(client *squarescale.Client) error {
+	env, err := squarescale.NewEnvironment(client, p.ProjectUUID)
+	if err != nil {
+		return err
+	}
+
+	current := env.Project.CustomVariables()
+
+	// variables missing from the manifest are kept
+	desired := map[string]string{}
+	for k, v := range current {
+		desired[k] = v
+	}
+	for k, v := range resolveRedacted(current, p.Manifest.Env) {
+		desired[k] = v
+	}
+
+	if details := envDetails(current, desired); len(details) > 0 {
+		p.add(Change{Action: Update, Kind: "env", Name: "project", Details: details, apply: setEnv})
+	}
+
+	declared := map[string]bool{}
+	for k := range p.Manifest.Env {
+		declared[k] = true
+	}
+	p.addDeletions("env variable", sortedKeys(current), declared)
+	return nil
+}
+
+// diff appends "field: current -> desired" to details when values differ
+func diff(details *[]string, field string, current, desired interface{}) {
+	c, d := fmt.Sprint(current), fmt.Sprint(desired)
+	if c != d {
+		*details = append(*details, fmt.Sprintf("%s: %s -> %s", field, c, d))
+	}
+}
+
+// resolveRedacted returns the desired variables, the redacted values being
+// replaced by the current ones or dropped when there is none
+func resolveRedacted(current, desired map[string]string) map[string]string {
+	if desired == nil {
+		return nil
+	}
+
+	resolved := make(map[string]string, len(desired))
+	for k, v := range desired {
+		if v != Redacted {
+			resolved[k] = v
+		} else if value, ok := current[k]; ok {
+			resolved[k] = value
+		}
+	}
+	return resolved
+}
+
+// envDetails lists the variables added, changed or removed without showing
+// their values, which may be secrets
+func envDetails(current, desired map[string]string) []string {
+	var details []string
+	for _, k := range sortedKeys(desired) {
+		value, ok := current[k]
+		if !ok {
+			details = append(details, fmt.Sprintf("env %s: added", k))
+		} else if value != desired[k] {
+			details = append(details, fmt.Sprintf("env %s: changed", k))
+		}
+	}
+	for _, k := range sortedKeys(current) {
+		if _, ok := desired[k]; !ok {
+			details = append(details, fmt.Sprintf("env %s: removed", k))
+		}
+	}
+	return details
+}
+
+// capabilities returns the sorted Docker capabilities, NONE standing for no
+// capability as in service add
+func capabilities(values []string) []string {
+	if len(values) == 1 && values[0] == NoDockerCapabilities {
+		return []string{}
+	}
+	return sortedList(values)
+}
+
+func sortedList(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
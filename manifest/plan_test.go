@@ -0,0 +1,407 @@
+package manifest_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/manifest"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// fakeAPI answers the requests with the body registered for "METHOD /path",
+// and 404 otherwise, recording them along with their body
+type fakeAPI struct {
+	*httptest.Server
+	routes   map[string]string
+	mu       sync.Mutex
+	requests []string
+	bodies   map[string]string
+}
+
+func newFakeAPI(routes map[string]string) *fakeAPI {
+	api := &fakeAPI{routes: routes, bodies: map[string]string{}}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+
+		api.mu.Lock()
+		api.requests = append(api.requests, route)
+		api.bodies[route] = string(body)
+		api.mu.Unlock()
+
+		res, ok := api.routes[route]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPut:
+			if strings.HasSuffix(r.URL.Path, "/environment/custom") {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.Write([]byte(res))
+	}))
+	return api
+}
+
+// writes lists the requests changing something
+func (api *fakeAPI) writes() []string {
+	var writes []string
+	for _, r := range api.requests {
+		if !strings.HasPrefix(r, "GET ") {
+			writes = append(writes, r)
+		}
+	}
+	return writes
+}
+
+const fullManifest = minimalManifest + `
+database:
+  engine: postgres
+  size: small
+services:
+  - name: web
+    image: nginx
+    instances: 2
+    env:
+      LOG_LEVEL: info
+env:
+  MODE: production
+`
+
+var existingProjectRoutes = map[string]string{
+	"GET /projects":      `[{"name": "my-project", "uuid": "project-uuid"}]`,
+	"GET /organizations": `[]`,
+	"GET /projects/project-uuid": `{
+		"name": "my-project", "provider": "aws", "region": "eu-west-1", "node_size": "t3.medium",
+		"infra_type": "high_availability", "root_disk_size_gb": 20, "cluster_size": 3, "hybrid_cluster_enabled": false,
+		"db_enabled": true, "db_engine": "postgres", "db_size": "small", "db_version": "14"
+	}`,
+	"GET /projects/project-uuid/services": `[{
+		"container_id": 1, "name": "web", "docker_image": {"name": "nginx"}, "size": 2, "auto_start": true, "max_client_disconnect": 0,
+		"limits": {"mem": 256, "cpu": 100},
+		"docker_capabilities": ["AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD", "NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT"],
+		"custom_environment": [{"key": "LOG_LEVEL", "value": "info"}, {"key": "PORT", "value": "80", "predefined": true}]
+	}]`,
+	"GET /projects/project-uuid/environment":                        `{"project": {"default": {}, "custom": {"MODE": "production"}}, "per_service": {}}`,
+	"GET /projects/project-uuid/redis_databases":                    `{"redis_database_configs": []}`,
+	"GET /projects/project-uuid/volumes":                            `[]`,
+	"GET /projects/project-uuid/scheduling_groups":                  `[]`,
+	"GET /projects/project-uuid/batches":                            `[]`,
+	"GET /projects/project-uuid/statefull_nodes":                    `[]`,
+	"GET /projects/project-uuid/network_policy":                     `[]`,
+	"GET /projects/project-uuid/services/web/service_network_rules": `[]`,
+}
+
+func TestPlan(t *testing.T) {
+	t.Run("Nominal case on NewPlan for a new project", nominalCaseOnNewPlanForNewProject)
+	t.Run("Test up to date project on NewPlan", upToDateProjectOnNewPlan)
+	t.Run("Test drift on NewPlan", driftOnNewPlan)
+	t.Run("Test immutable setting on NewPlan", immutableSettingOnNewPlan)
+	t.Run("Test immutable project settings on NewPlan", immutableProjectSettingsOnNewPlan)
+	t.Run("Test image change on NewPlan", imageChangeOnNewPlan)
+	t.Run("Test resources missing from the manifest on NewPlan", missingResourcesOnNewPlan)
+	t.Run("Nominal case on Apply for a new project", nominalCaseOnApplyForNewProject)
+	t.Run("Test entrypoint update on Apply", entrypointUpdateOnApply)
+	t.Run("Test API error on Apply", apiErrorOnApply)
+}
+
+func routesWith(changes map[string]string) map[string]string {
+	routes := map[string]string{}
+	for k, v := range existingProjectRoutes {
+		routes[k] = v
+	}
+	for k, v := range changes {
+		routes[k] = v
+	}
+	return routes
+}
+
+func nominalCaseOnNewPlanForNewProject(t *testing.T) {
+	// given
+	api := newFakeAPI(map[string]string{
+		"GET /projects":      `[]`,
+		"GET /organizations": `[]`,
+	})
+	defer api.Close()
+	m, _ := manifest.Parse([]byte(fullManifest))
+
+	// when
+	plan, err := manifest.NewPlan(squarescale.NewClient(api.URL, "some-token"), m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	expected := "+ project my-project\n+ service web\n+ env project\n    env MODE: added"
+	if plan.String() != expected {
+		t.Errorf("Expect plan\n%s\ngot\n%s", expected, plan.String())
+	}
+}
+
+func upToDateProjectOnNewPlan(t *testing.T) {
+	// given
+	api := newFakeAPI(existingProjectRoutes)
+	defer api.Close()
+	m, _ := manifest.Parse([]byte(strings.Replace(fullManifest, "size: small", "size: small\n  version: \"14\"", 1)))
+
+	// when
+	plan, err := manifest.NewPlan(squarescale.NewClient(api.URL, "some-token"), m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if plan.HasChanges() {
+		t.Errorf("Expect no changes, got\n%s", plan.String())
+	}
+
+	if plan.ProjectUUID != "project-uuid" {
+		t.Errorf("Expect project UUID `%s`, got `%s`", "project-uuid", plan.ProjectUUID)
+	}
+}
+
+func driftOnNewPlan(t *testing.T) {
+	// given
+	api := newFakeAPI(routesWith(map[string]string{
+		"GET /projects/project-uuid/environment": `{"project": {"default": {}, "custom": {"MODE": "staging", "OTHER": "kept"}}, "per_service": {}}`,
+	}))
+	defer api.Close()
+	data := strings.Replace(fullManifest, "instances: 2", "instances: 3", 1)
+	data = strings.Replace(data, "LOG_LEVEL: info", "LOG_LEVEL: debug", 1)
+	m, _ := manifest.Parse([]byte(data))
+
+	// when
+	plan, err := manifest.NewPlan(squarescale.NewClient(api.URL, "some-token"), m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	expected := "~ service web\n    instances: 2 -> 3\n    env LOG_LEVEL: changed\n~ env project\n    env MODE: changed\n- env variable OTHER"
+	if plan.String() != expected {
+		t.Errorf("Expect plan\n%s\ngot\n%s", expected, plan.String())
+	}
+
+	if strings.Contains(plan.String(), "staging") || strings.Contains(plan.String(), "debug") {
+		t.Errorf("Expect plan not to show variable values, got\n%s", plan.String())
+	}
+}
+
+func immutableSettingOnNewPlan(t *testing.T) {
+	// given
+	api := newFakeAPI(existingProjectRoutes)
+	defer api.Close()
+	m, _ := manifest.Parse([]byte(strings.Replace(minimalManifest, "eu-west-1", "us-east-1", 1)))
+
+	// when
+	plan, err := manifest.NewPlan(squarescale.NewClient(api.URL, "some-token"), m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	expected := "-/+ project my-project\n    region: eu-west-1 -> us-east-1\n"
+	if !strings.HasPrefix(plan.String(), expected) {
+		t.Errorf("Expect plan\n%s\ngot\n%s", expected, plan.String())
+	}
+
+	expectedError := "Cannot change project 'my-project' once created (region: eu-west-1 -> us-east-1)"
+	if err := plan.Applicable(); err == nil || err.Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, err)
+	}
+}
+
+func immutableProjectSettingsOnNewPlan(t *testing.T) {
+	// given
+	api := newFakeAPI(existingProjectRoutes)
+	defer api.Close()
+	data := minimalManifest + "  monitoring: netdata\n  slack-webhook: https://hooks.slack.com/services/secret\n"
+	m, _ := manifest.Parse([]byte(data))
+
+	// when
+	plan, err := manifest.NewPlan(squarescale.NewClient(api.URL, "some-token"), m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	expectedError := "Cannot change project 'my-project' once created (monitoring:  -> netdata, slack-webhook: changed)"
+	if err := plan.Applicable(); err == nil || err.Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, err)
+	}
+}
+
+func imageChangeOnNewPlan(t *testing.T) {
+	// given
+	api := newFakeAPI(existingProjectRoutes)
+	defer api.Close()
+	data := strings.Replace(fullManifest, "size: small", "size: small\n  version: \"14\"", 1)
+	m, _ := manifest.Parse([]byte(strings.Replace(data, "image: nginx", "image: nginx:1.25", 1)))
+
+	client := squarescale.NewClient(api.URL, "some-token")
+
+	// when
+	plan, err := manifest.NewPlan(client, m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != manifest.Replace || plan.Changes[0].Kind != "service" {
+		t.Fatalf("Expect the service to be replaced, got `%v`", plan.Changes)
+	}
+
+	applied, err := plan.Apply(client)
+	expectedError := "Cannot change service 'web' once created (image: nginx -> nginx:1.25)"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, err)
+	}
+	if applied != 0 || len(api.writes()) != 0 {
+		t.Errorf("Expect no change to be applied, got requests `%v`", api.writes())
+	}
+}
+
+func missingResourcesOnNewPlan(t *testing.T) {
+	// given
+	api := newFakeAPI(routesWith(map[string]string{
+		"GET /projects/project-uuid/redis_databases": `{"redis_database_configs": [{"name": "cache"}]}`,
+		"GET /projects/project-uuid/environment":     `{"project": {"default": {"DB_HOST": "db"}, "custom": {"MODE": "production", "OTHER": "value"}}, "per_service": {}}`,
+	}))
+	defer api.Close()
+	m, _ := manifest.Parse([]byte(strings.Replace(fullManifest, "size: small", "size: small\n  version: \"14\"", 1)))
+
+	// when
+	plan, err := manifest.NewPlan(squarescale.NewClient(api.URL, "some-token"), m)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	expected := "- redis cache\n- env variable OTHER"
+	if plan.String() != expected {
+		t.Errorf("Expect plan\n%s\ngot\n%s", expected, plan.String())
+	}
+
+	if len(plan.Pending()) != 0 {
+		t.Errorf("Expect no pending changes, got `%v`", plan.Pending())
+	}
+}
+
+func nominalCaseOnApplyForNewProject(t *testing.T) {
+	// given
+	api := newFakeAPI(map[string]string{
+		"GET /projects":                             `[]`,
+		"GET /organizations":                        `[]`,
+		"POST /projects":                            `{"name": "my-project", "uuid": "new-uuid"}`,
+		"POST /projects/new-uuid/docker_images":     `{}`,
+		"GET /projects/new-uuid/environment":        `{"project": {"default": {}, "custom": {}}, "per_service": {}}`,
+		"PUT /projects/new-uuid/environment/custom": ``,
+	})
+	defer api.Close()
+	client := squarescale.NewClient(api.URL, "some-token")
+	m, _ := manifest.Parse([]byte(fullManifest))
+	plan, err := manifest.NewPlan(client, m)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// when
+	applied, err := plan.Apply(client)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+	if applied != len(plan.Pending()) {
+		t.Errorf("Expect %d changes to be applied, got %d", len(plan.Pending()), applied)
+	}
+
+	expected := "POST /projects, POST /projects/new-uuid/docker_images, PUT /projects/new-uuid/environment/custom"
+	if strings.Join(api.writes(), ", ") != expected {
+		t.Errorf("Expect requests `%s`, got `%s`", expected, strings.Join(api.writes(), ", "))
+	}
+
+	if !strings.Contains(api.bodies["POST /projects"], `"databases":[{`) {
+		t.Errorf("Expect the database to be created with the project, got `%s`", api.bodies["POST /projects"])
+	}
+
+	if !strings.Contains(api.bodies["PUT /projects/new-uuid/environment/custom"], `"MODE":"production"`) {
+		t.Errorf("Expect the project environment to be set, got `%s`", api.bodies["PUT /projects/new-uuid/environment/custom"])
+	}
+}
+
+func entrypointUpdateOnApply(t *testing.T) {
+	// given
+	api := newFakeAPI(routesWith(map[string]string{
+		"PUT /containers/1": `{}`,
+	}))
+	defer api.Close()
+	client := squarescale.NewClient(api.URL, "some-token")
+	data := strings.Replace(fullManifest, "size: small", "size: small\n  version: \"14\"", 1)
+	m, _ := manifest.Parse([]byte(strings.Replace(data, "image: nginx", "image: nginx\n    entrypoint: /start.sh", 1)))
+	plan, err := manifest.NewPlan(client, m)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// when
+	applied, err := plan.Apply(client)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+	if applied != len(plan.Pending()) {
+		t.Errorf("Expect %d changes to be applied, got %d", len(plan.Pending()), applied)
+	}
+
+	expected := "PUT /containers/1"
+	if strings.Join(api.writes(), ", ") != expected {
+		t.Errorf("Expect requests `%s`, got `%s`", expected, strings.Join(api.writes(), ", "))
+	}
+
+	if !strings.Contains(api.bodies["PUT /containers/1"], `"entrypoint":"/start.sh"`) {
+		t.Errorf("Expect the entrypoint to be updated, got `%s`", api.bodies["PUT /containers/1"])
+	}
+}
+
+func apiErrorOnApply(t *testing.T) {
+	// given
+	api := newFakeAPI(map[string]string{
+		"GET /projects":      `[]`,
+		"GET /organizations": `[]`,
+	})
+	defer api.Close()
+	client := squarescale.NewClient(api.URL, "some-token")
+	m, _ := manifest.Parse([]byte(fullManifest))
+	plan, _ := manifest.NewPlan(client, m)
+
+	// when
+	applied, err := plan.Apply(client)
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "Cannot create project 'my-project': ") {
+		t.Fatalf("Expect project creation error, got `%v`", err)
+	}
+
+	if applied != 0 || len(api.writes()) != 1 {
+		t.Errorf("Expect Apply to stop at the first error, got requests `%v`", api.writes())
+	}
+}
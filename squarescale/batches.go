@@ -73,24 +73,24 @@ func (c *Client) CreateBatch(uuid string, batchOrderContent BatchOrder) (Created
 		payload["docker_devices"] = batchOrderContent.BatchCommon.DockerDevices
 	}
 
-	code, body, err := c.post("/projects/"+uuid+"/batches", payload)
+	res, err := c.post("/projects/"+uuid+"/batches", payload)
 	if err != nil {
 		return CreatedBatch{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 	case http.StatusNotFound:
-		return CreatedBatch{}, fmt.Errorf("Project '%s' does not exist", uuid)
+		return CreatedBatch{}, res.errorf("Project '%s' does not exist", uuid)
 	case http.StatusConflict:
-		return CreatedBatch{}, fmt.Errorf("Batch already exist on project '%s'", uuid)
+		return CreatedBatch{}, res.errorf("Batch already exist on project '%s'", uuid)
 	default:
-		return CreatedBatch{}, unexpectedHTTPError(code, body)
+		return CreatedBatch{}, res.unexpectedError()
 	}
 
 	var createdBatch CreatedBatch
 
-	if err := json.Unmarshal(body, &createdBatch); err != nil {
+	if err := json.Unmarshal(res.body, &createdBatch); err != nil {
 		return CreatedBatch{}, err
 	}
 
@@ -125,22 +125,22 @@ type BatchDockerImage struct {
 }
 
 func (c *Client) GetBatches(uuid string) ([]RunningBatch, error) {
-	code, body, err := c.get("/projects/" + uuid + "/batches")
+	res, err := c.get("/projects/" + uuid + "/batches")
 	if err != nil {
 		return []RunningBatch{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []RunningBatch{}, fmt.Errorf("Project '%s' does not exist", uuid)
+		return []RunningBatch{}, res.errorf("Project '%s' does not exist", uuid)
 	default:
-		return []RunningBatch{}, unexpectedHTTPError(code, body)
+		return []RunningBatch{}, res.unexpectedError()
 	}
 
 	var batchesByID []RunningBatch
 
-	if err := json.Unmarshal(body, &batchesByID); err != nil {
+	if err := json.Unmarshal(res.body, &batchesByID); err != nil {
 		return []RunningBatch{}, err
 	}
 
@@ -150,22 +150,22 @@ func (c *Client) GetBatches(uuid string) ([]RunningBatch, error) {
 
 // DeleteBatch delete a existing batch
 func (c *Client) DeleteBatch(uuid string, name string) error {
-	code, body, err := c.delete("/projects/" + uuid + "/batches/" + name)
+	res, err := c.delete("/projects/" + uuid + "/batches/" + name)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		if fmt.Sprintf("%s", body) == `{"error":"Couldn't find Batch with [WHERE \"batches\".\"cluster_id\" = $1 AND \"batches\".\"name\" = $2]"}` {
-			return fmt.Errorf("Batch '%s' does not exist", name)
+		if fmt.Sprintf("%s", res.body) == `{"error":"Couldn't find Batch with [WHERE \"batches\".\"cluster_id\" = $1 AND \"batches\".\"name\" = $2]"}` {
+			return res.errorf("Batch '%s' does not exist", name)
 		}
-		return fmt.Errorf("Project '%s' does not exist", uuid)
+		return res.errorf("Project '%s' does not exist", uuid)
 	case http.StatusBadRequest:
-		return fmt.Errorf("Deploy probably in progress")
+		return res.errorf("Deploy probably in progress")
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -174,13 +174,13 @@ func (c *Client) DeleteBatch(uuid string, name string) error {
 // ExecuteBatch execute an existing batch
 func (c *Client) ExecuteBatch(projectUUID, name string) error {
 	url := fmt.Sprintf("/projects/%s/batches/%s/execute", projectUUID, name)
-	code, body, err := c.post(url, nil)
+	res, err := c.post(url, nil)
 	if err != nil {
 		return err
 	}
 
-	if code != http.StatusOK {
-		return unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -198,7 +198,7 @@ func (c *Client) GetBatchesInfo(projectUUID, name string) (RunningBatch, error)
 		}
 	}
 
-	return RunningBatch{}, fmt.Errorf("Batch %q not found for project %q", name, projectUUID)
+	return RunningBatch{}, notFoundError("/projects/"+projectUUID+"/batches", "Batch %q not found for project %q", name, projectUUID)
 }
 
 func (c *RunningBatch) SetEnv(path string) error {
@@ -229,6 +229,9 @@ func (c *Client) ConfigBatch(batch RunningBatch, projectUUID string) error {
 	if len(batch.RunCommand) != 0 {
 		payload["run_command"] = batch.RunCommand
 	}
+	if len(batch.Entrypoint) != 0 {
+		payload["entrypoint"] = batch.Entrypoint
+	}
 	limits := JSONObject{}
 	if batch.Limits.Memory >= 0 {
 		limits["mem"] = batch.Limits.Memory
@@ -251,17 +254,17 @@ func (c *Client) ConfigBatch(batch RunningBatch, projectUUID string) error {
 	}
 
 	logger.Debug.Println("Json payload : ", payload)
-	code, body, err := c.put(fmt.Sprintf("/projects/%s/batches/%s", projectUUID, batch.BatchCommon.Name), payload)
+	res, err := c.put(fmt.Sprintf("/projects/%s/batches/%s", projectUUID, batch.BatchCommon.Name), payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return errors.New("Batch does not exist")
+		return res.errorf("Batch does not exist")
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
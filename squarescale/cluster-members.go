@@ -18,22 +18,22 @@ type ClusterMember struct {
 
 // GetClusterMembers gets all the cluster members attached to a Project
 func (c *Client) GetClusterMembers(projectUUID string) ([]ClusterMember, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/cluster_members")
+	res, err := c.get("/projects/" + projectUUID + "/cluster_members")
 	if err != nil {
 		return []ClusterMember{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []ClusterMember{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []ClusterMember{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []ClusterMember{}, unexpectedHTTPError(code, body)
+		return []ClusterMember{}, res.unexpectedError()
 	}
 
 	var clusterMembersByID []ClusterMember
 
-	if err := json.Unmarshal(body, &clusterMembersByID); err != nil {
+	if err := json.Unmarshal(res.body, &clusterMembersByID); err != nil {
 		return []ClusterMember{}, err
 	}
 
@@ -53,24 +53,24 @@ func (c *Client) GetClusterMemberInfo(projectUUID string, name string) (ClusterM
 		}
 	}
 
-	return ClusterMember{}, fmt.Errorf("Cluster node '%s' not found for project '%s'", name, projectUUID)
+	return ClusterMember{}, notFoundError("/projects/"+projectUUID+"/cluster_members", "Cluster node '%s' not found for project '%s'", name, projectUUID)
 }
 
 // ConfigClusterMember set information for a cluster member
 func (c *Client) ConfigClusterMember(projectUUID string, clusterMember ClusterMember, newClusterMember ClusterMember) error {
 	payload := &JSONObject{"scheduling_group": newClusterMember.SchedulingGroup.ID}
 
-	code, body, err := c.put(fmt.Sprintf("/projects/%s/cluster_members/%d", projectUUID, clusterMember.ID), payload)
+	res, err := c.put(fmt.Sprintf("/projects/%s/cluster_members/%d", projectUUID, clusterMember.ID), payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' not found", projectUUID)
+		return res.errorf("Project '%s' not found", projectUUID)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
@@ -9,17 +9,17 @@ import (
 
 // GetClusterSize asks the SquareScale API for the cluster size of a project.
 func (c *Client) GetClusterConfig(projectUUID string) (*ClusterConfig, error) {
-	code, body, err := c.get("/projects/" + projectUUID)
+	res, err := c.get("/projects/" + projectUUID)
 	if err != nil {
 		return nil, err
 	}
 
-	if code != http.StatusOK {
-		return nil, unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return nil, res.unexpectedError()
 	}
 
 	var cluster ClusterConfig
-	err = json.Unmarshal(body, &cluster)
+	err = json.Unmarshal(res.body, &cluster)
 	if err != nil {
 		return nil, err
 	}
@@ -33,12 +33,12 @@ func (c *Client) ConfigCluster(projectUUID string, cluster *ClusterConfig) (task
 		"cluster": cluster.ConfigSettings(),
 	}
 
-	code, body, err := c.post("/projects/"+projectUUID+"/cluster", payload)
+	res, err := c.post("/projects/"+projectUUID+"/cluster", payload)
 	if err != nil {
 		return 0, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusAccepted:
 		fallthrough
 	case http.StatusOK:
@@ -46,9 +46,9 @@ func (c *Client) ConfigCluster(projectUUID string, cluster *ClusterConfig) (task
 	case http.StatusNoContent:
 		return 0, nil
 	case http.StatusUnprocessableEntity:
-		return 0, fmt.Errorf("Invalid value for cluster size ('%d')", cluster.Size)
+		return 0, res.errorf("Invalid value for cluster size ('%d')", cluster.Size)
 	default:
-		return 0, unexpectedHTTPError(code, body)
+		return 0, res.unexpectedError()
 	}
 
 	var resp struct {
@@ -56,7 +56,7 @@ func (c *Client) ConfigCluster(projectUUID string, cluster *ClusterConfig) (task
 		Task       int `json:"task"`
 	}
 
-	err = json.Unmarshal(body, &resp)
+	err = json.Unmarshal(res.body, &resp)
 	if err != nil {
 		return 0, err
 	}
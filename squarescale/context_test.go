@@ -0,0 +1,111 @@
+package squarescale_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+func TestContext(t *testing.T) {
+	t.Run("Test cancelled context on hung API call", CancelledContextOnHungCall)
+	t.Run("Test deadline on hung API call", DeadlineOnHungCall)
+	t.Run("Test wait timeout on hung API call", WaitTimeoutOnHungCall)
+	t.Run("Test WithContext keeps the original client", WithContextKeepsOriginalClient)
+}
+
+// hungServer never answers until the test ends
+func hungServer(t *testing.T) (*httptest.Server, func()) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+
+	return server, func() {
+		close(release)
+		server.Close()
+	}
+}
+
+func CancelledContextOnHungCall(t *testing.T) {
+	// given
+	server, stop := hungServer(t)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cli := squarescale.NewClient(server.URL, "some-token").WithContext(ctx)
+
+	// when
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := cli.GetProject("8cfe8f68-cad5-4157-b8a6-d9efa12caf0e")
+
+	// then
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expect context.Canceled, got `%v`", err)
+	}
+}
+
+func DeadlineOnHungCall(t *testing.T) {
+	// given
+	server, stop := hungServer(t)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	cli := squarescale.NewClient(server.URL, "some-token").WithContext(ctx)
+
+	// when
+	_, err := cli.ListProjects()
+
+	// then
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expect context.DeadlineExceeded, got `%v`", err)
+	}
+}
+
+func WaitTimeoutOnHungCall(t *testing.T) {
+	// given
+	server, stop := hungServer(t)
+	defer stop()
+
+	cli := squarescale.NewClient(server.URL, "some-token")
+	opts := squarescale.WaitOptions{Timeout: 10 * time.Millisecond}
+
+	// when
+	_, err := cli.WaitProjectWithOptions("8cfe8f68-cad5-4157-b8a6-d9efa12caf0e", opts)
+
+	// then
+	var timeoutErr *squarescale.WaitTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expect a WaitTimeoutError, got `%v`", err)
+	}
+}
+
+func WithContextKeepsOriginalClient(t *testing.T) {
+	// given
+	cli := squarescale.NewClient("http://localhost", "some-token")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	bound := cli.WithContext(ctx)
+
+	// then
+	if bound.Context() != ctx {
+		t.Fatalf("Expect bound client to use the given context")
+	}
+
+	if cli.Context() != context.Background() {
+		t.Fatalf("Expect original client to keep the background context")
+	}
+}
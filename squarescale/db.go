@@ -48,18 +48,18 @@ func (db *DbConfig) String() string {
 // GetAvailableDBSizes return the db node size available for a cloud provider
 // on a given region
 func (c *Client) GetAvailableDBSizes(provider, region string) ([]DataseSize, error) {
-	code, body, err := c.get(fmt.Sprintf("/infra/providers/%s/regions/%s/database_sizes", provider, url.PathEscape(region)))
+	res, err := c.get(fmt.Sprintf("/infra/providers/%s/regions/%s/database_sizes", provider, url.PathEscape(region)))
 	if err != nil {
 		return nil, err
 	}
 
-	if code != http.StatusOK {
-		return nil, unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return nil, res.unexpectedError()
 	}
 
 	var sizes []DataseSize
 
-	err = json.Unmarshal(body, &sizes)
+	err = json.Unmarshal(res.body, &sizes)
 	if err != nil {
 		return nil, err
 	}
@@ -70,17 +70,17 @@ func (c *Client) GetAvailableDBSizes(provider, region string) ([]DataseSize, err
 // GetAvailableDBEngines returns all the database engines available for a cloud provider
 // on a given region
 func (c *Client) GetAvailableDBEngines(provider, region string) ([]DataseEngine, error) {
-	code, body, err := c.get(fmt.Sprintf("/infra/providers/%s/regions/%s/database_engines", provider, url.PathEscape(region)))
+	res, err := c.get(fmt.Sprintf("/infra/providers/%s/regions/%s/database_engines", provider, url.PathEscape(region)))
 	if err != nil {
 		return nil, err
 	}
 
-	if code != http.StatusOK {
-		return nil, unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return nil, res.unexpectedError()
 	}
 
 	var enginesList []DataseEngine
-	err = json.Unmarshal(body, &enginesList)
+	err = json.Unmarshal(res.body, &enginesList)
 	if err != nil {
 		return nil, err
 	}
@@ -94,17 +94,17 @@ func (c *Client) GetAvailableDBEngines(provider, region string) ([]DataseEngine,
 // - the db engine in use (string)
 // - the db instance in use (string)
 func (c *Client) GetDBConfig(uuid string) (*DbConfig, error) {
-	code, body, err := c.get("/projects/" + uuid)
+	res, err := c.get("/projects/" + uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	if code != http.StatusOK {
-		return nil, unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return nil, res.unexpectedError()
 	}
 
 	var db DbConfig
-	err = json.Unmarshal(body, &db)
+	err = json.Unmarshal(res.body, &db)
 	if err != nil {
 		return nil, err
 	}
@@ -115,12 +115,12 @@ func (c *Client) GetDBConfig(uuid string) (*DbConfig, error) {
 // ConfigDB calls the SquareScale API to update database options for a given project.
 func (c *Client) ConfigDB(uuid string, payload *JSONObject) (taskId int, err error) {
 
-	code, body, err := c.put("/projects/"+uuid+"/database", payload)
+	res, err := c.put("/projects/"+uuid+"/database", payload)
 	if err != nil {
 		return 0, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusAccepted:
 		fallthrough
 	case http.StatusOK:
@@ -128,7 +128,7 @@ func (c *Client) ConfigDB(uuid string, payload *JSONObject) (taskId int, err err
 	case http.StatusNoContent:
 		return 0, nil
 	default:
-		return 0, unexpectedHTTPError(code, body)
+		return 0, res.unexpectedError()
 	}
 
 	var resp struct {
@@ -136,7 +136,7 @@ func (c *Client) ConfigDB(uuid string, payload *JSONObject) (taskId int, err err
 		Task   int `json:"task"`
 	}
 
-	err = json.Unmarshal(body, &resp)
+	err = json.Unmarshal(res.body, &resp)
 	if err != nil {
 		return 0, err
 	}
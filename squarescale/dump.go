@@ -0,0 +1,48 @@
+package squarescale
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/squarescale/squarescale-cli/redact"
+)
+
+// sensitiveHeaders are the request headers holding credentials
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// sensitiveFields are the JSON fields of request bodies holding credentials
+// whatever the redaction patterns, such as the username of private Docker
+// images, along with the password matched by the patterns
+var sensitiveFields = []string{"username", "password", "secret_key"}
+
+// dumpRequest returns the request as sent on the wire, for trace logs, the
+// credentials of its headers and the secrets of its JSON body being redacted.
+// The request itself is left untouched.
+func dumpRequest(req *http.Request, body []byte) (string, error) {
+	dumped := req.Clone(req.Context())
+	for _, header := range sensitiveHeaders {
+		if value := dumped.Header.Get(header); value != "" {
+			dumped.Header.Set(header, redactCredentials(header, value))
+		}
+	}
+
+	dump, err := httputil.DumpRequestOut(dumped, false)
+	if err != nil {
+		return "", err
+	}
+
+	if len(body) == 0 {
+		return string(dump), nil
+	}
+	return string(dump) + string(redact.JSON(body, sensitiveFields...)), nil
+}
+
+// redactCredentials redacts the value of a header, keeping the
+// authentication scheme of authorizations, such as bearer
+func redactCredentials(header, value string) string {
+	if i := strings.Index(value, " "); i > 0 && strings.HasSuffix(header, "Authorization") {
+		return value[:i+1] + redact.Redacted
+	}
+	return redact.Redacted
+}
@@ -12,28 +12,28 @@ import (
 // - Services variables in an array of VariableGroup with a service Name and
 // variables defined in the service only for each
 type Environment struct {
-	Project  *VariableGroup
-	Services []*VariableGroup
+	Project  *VariableGroup   `json:"project"`
+	Services []*VariableGroup `json:"services"`
 }
 
 // NewEnvironment fetches environment variables from the API and returns a
 // new Environment pointer if successful and an error otherwise.
 func NewEnvironment(c *Client, projectUUID string) (*Environment, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/environment")
+	res, err := c.get("/projects/" + projectUUID + "/environment")
 	if err != nil {
 		return nil, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return nil, fmt.Errorf("Project '%s' not found", projectUUID)
+		return nil, res.errorf("Project '%s' not found", projectUUID)
 	default:
-		return nil, unexpectedHTTPError(code, body)
+		return nil, res.unexpectedError()
 	}
 
 	var env Environment
-	if err = json.Unmarshal(body, &env); err != nil {
+	if err = json.Unmarshal(res.body, &env); err != nil {
 		return nil, err
 	}
 
@@ -143,20 +143,20 @@ func (env *Environment) CommitEnvironment(c *Client, projectUUID string) error {
 	}
 	requestBody := &JSONObject{"environment": envJSON, "format": "json"}
 
-	code, body, err := c.put("/projects/"+projectUUID+"/environment/custom", requestBody)
+	res, err := c.put("/projects/"+projectUUID+"/environment/custom", requestBody)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusNoContent:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' not found", projectUUID)
+		return res.errorf("Project '%s' not found", projectUUID)
 	case http.StatusUnprocessableEntity:
-		return fmt.Errorf("%s", body)
+		return res.errorf("%s", res.body)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
 
@@ -169,14 +169,14 @@ func (env *Environment) GetServiceGroup(serviceName string) (*VariableGroup, err
 			return vg, nil
 		}
 	}
-	return nil, fmt.Errorf("Could not find container '%s'", serviceName)
+	return nil, notFoundError("/environment", "Could not find container '%s'", serviceName)
 }
 
 // VariableGroup holds a Name and an array of Variable for a specific group
 // like a service or the global part of a project environment variables.
 type VariableGroup struct {
-	Name      string
-	Variables []*Variable
+	Name      string      `json:"name"`
+	Variables []*Variable `json:"variables"`
 }
 
 // GetVariable finds a Variable in the VariableGroup Variables field with the
@@ -193,9 +193,8 @@ func (vg *VariableGroup) GetVariable(variableName string) (*Variable, error) {
 		}
 	}
 	if finalVar == nil {
-		err := fmt.Errorf("Could not find variable '%s' for container '%s'",
+		return nil, notFoundError("/environment", "Could not find variable '%s' for container '%s'",
 			variableName, vg.Name)
-		return nil, err
 	}
 
 	return finalVar, nil
@@ -229,12 +228,13 @@ func (vg *VariableGroup) RemoveVariable(key string) error {
 	var predefinedOnly = false
 
 	variables := vg.Variables
-	for i, variable := range variables {
-		if variable.Key == key {
+	for i := 0; i < len(variables); i++ {
+		if variable := variables[i]; variable.Key == key {
 			predefinedOnly = variable.Predefined
 
 			if !predefinedOnly {
 				variables = remove(variables, i)
+				i--
 			}
 		}
 	}
@@ -249,9 +249,9 @@ func (vg *VariableGroup) RemoveVariable(key string) error {
 // Variable represents an environment variable which has a Key, a Value and is
 // Predefined or custom (i.e. not Predefined).
 type Variable struct {
-	Key        string
-	Value      string
-	Predefined bool
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+	Predefined bool   `json:"predefined"`
 }
 
 func merge(bases, overrides []*Variable) []*Variable {
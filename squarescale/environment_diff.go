@@ -0,0 +1,78 @@
+package squarescale
+
+import (
+	"fmt"
+	"sort"
+)
+
+// VariableDiff is a difference between two VariableGroup for a variable:
+// From is nil when the variable was added, To is nil when it was removed.
+type VariableDiff struct {
+	Key  string    `json:"key"`
+	From *Variable `json:"from,omitempty"`
+	To   *Variable `json:"to,omitempty"`
+}
+
+// DiffVariables compares the variables of two VariableGroup and returns the
+// variables which were added, removed, changed or which became custom or
+// predefined, sorted by name.
+func DiffVariables(from, to *VariableGroup) []VariableDiff {
+	variables := map[string]*VariableDiff{}
+	for _, variable := range from.Variables {
+		variables[variable.Key] = &VariableDiff{Key: variable.Key, From: variable}
+	}
+	for _, variable := range to.Variables {
+		if diff, ok := variables[variable.Key]; ok {
+			diff.To = variable
+		} else {
+			variables[variable.Key] = &VariableDiff{Key: variable.Key, To: variable}
+		}
+	}
+
+	diffs := []VariableDiff{}
+	for _, diff := range variables {
+		if diff.From != nil && diff.To != nil && *diff.From == *diff.To {
+			continue
+		}
+		diffs = append(diffs, *diff)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Key < diffs[j].Key })
+
+	return diffs
+}
+
+// String returns a one line representation of the difference, such as
+// "~ DB_NAME (predefined -> custom)", with the values when showValues is set.
+func (d VariableDiff) String(showValues bool) string {
+	switch {
+	case d.From == nil:
+		line := fmt.Sprintf("+ %s (%s)", d.Key, d.To.kind())
+		if showValues {
+			line += ": " + d.To.Value
+		}
+		return line
+	case d.To == nil:
+		line := fmt.Sprintf("- %s (%s)", d.Key, d.From.kind())
+		if showValues {
+			line += ": " + d.From.Value
+		}
+		return line
+	}
+
+	kind := d.From.kind()
+	if d.To.kind() != kind {
+		kind += " -> " + d.To.kind()
+	}
+	line := fmt.Sprintf("~ %s (%s)", d.Key, kind)
+	if showValues && d.From.Value != d.To.Value {
+		line += fmt.Sprintf(": %s -> %s", d.From.Value, d.To.Value)
+	}
+	return line
+}
+
+func (v *Variable) kind() string {
+	if v.Predefined {
+		return "predefined"
+	}
+	return "custom"
+}
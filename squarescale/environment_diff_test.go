@@ -0,0 +1,62 @@
+package squarescale_test
+
+import (
+	. "github.com/squarescale/squarescale-cli/squarescale"
+)
+
+var _ = Describe("Environment diff", func() {
+	from := &VariableGroup{
+		Name: "staging",
+		Variables: []*Variable{
+			{Key: "DB_NAME", Value: "staging", Predefined: true},
+			{Key: "LOG_LEVEL", Value: "debug"},
+			{Key: "SAME", Value: "same"},
+			{Key: "REMOVED", Value: "removed"},
+		},
+	}
+	to := &VariableGroup{
+		Name: "production",
+		Variables: []*Variable{
+			{Key: "DB_NAME", Value: "staging"},
+			{Key: "LOG_LEVEL", Value: "info"},
+			{Key: "SAME", Value: "same"},
+			{Key: "ADDED", Value: "added", Predefined: true},
+		},
+	}
+
+	Describe("DiffVariables", func() {
+		It("returns the differences sorted by name", func() {
+			diffs := DiffVariables(from, to)
+
+			var lines []string
+			for _, diff := range diffs {
+				lines = append(lines, diff.String(false))
+			}
+			Expect(lines).To(Equal([]string{
+				"+ ADDED (predefined)",
+				"~ DB_NAME (predefined -> custom)",
+				"~ LOG_LEVEL (custom)",
+				"- REMOVED (custom)",
+			}))
+		})
+
+		It("shows the values on demand", func() {
+			diffs := DiffVariables(from, to)
+
+			var lines []string
+			for _, diff := range diffs {
+				lines = append(lines, diff.String(true))
+			}
+			Expect(lines).To(Equal([]string{
+				"+ ADDED (predefined): added",
+				"~ DB_NAME (predefined -> custom)",
+				"~ LOG_LEVEL (custom): debug -> info",
+				"- REMOVED (custom): removed",
+			}))
+		})
+
+		It("returns nothing on identical groups", func() {
+			Expect(DiffVariables(from, from)).To(BeEmpty())
+		})
+	})
+})
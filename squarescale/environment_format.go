@@ -0,0 +1,318 @@
+package squarescale
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the environment variables files
+const (
+	EnvFormatDotenv = "dotenv"
+	EnvFormatJSON   = "json"
+	EnvFormatYAML   = "yaml"
+	EnvFormatShell  = "shell"
+)
+
+// EnvFormats lists the formats of the environment variables files
+var EnvFormats = []string{EnvFormatDotenv, EnvFormatJSON, EnvFormatYAML, EnvFormatShell}
+
+var (
+	validKey  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	safeValue = regexp.MustCompile(`^[A-Za-z0-9_./:@,+=%-]*$`)
+)
+
+// ValidVariableName tells whether key can be used as an environment variable
+// name
+func ValidVariableName(key string) bool {
+	return validKey.MatchString(key)
+}
+
+// CustomVariables returns the custom variables of the VariableGroup.
+func (vg *VariableGroup) CustomVariables() map[string]string {
+	variables := map[string]string{}
+	for _, variable := range vg.Variables {
+		if !variable.Predefined {
+			variables[variable.Key] = variable.Value
+		}
+	}
+	return variables
+}
+
+// FormatVariables renders the variables in the given format, sorted by name:
+// - dotenv: KEY=value lines, values being quoted when needed
+// - shell: export KEY='value' lines, to be evaluated by a POSIX shell
+// - json and yaml: an object of the variables
+func FormatVariables(variables map[string]string, format string) (string, error) {
+	switch format {
+	case EnvFormatJSON:
+		data, err := json.MarshalIndent(variables, "", "  ")
+		return string(data), err
+	case EnvFormatYAML:
+		if len(variables) == 0 {
+			return "{}", nil
+		}
+		data, err := yaml.Marshal(variables)
+		return strings.TrimSuffix(string(data), "\n"), err
+	case EnvFormatDotenv, EnvFormatShell:
+	default:
+		return "", fmt.Errorf("Unknown format: %s. Correct values are %s", format, strings.Join(EnvFormats, ", "))
+	}
+
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, key := range keys {
+		if format == EnvFormatShell {
+			lines = append(lines, fmt.Sprintf("export %s=%s", key, shellQuote(variables[key])))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s=%s", key, dotenvQuote(variables[key])))
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// dotenvQuote leaves simple values as is, single quotes the values without
+// newlines nor single quotes, and double quotes and escapes the others
+func dotenvQuote(value string) string {
+	if safeValue.MatchString(value) {
+		return value
+	}
+	if !strings.ContainsAny(value, "'\n\r") {
+		return "'" + value + "'"
+	}
+
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"$", `\$`,
+		"`", "\\`",
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// shellQuote single quotes the value, closing and reopening the quotes
+// around the escaped single quotes it contains
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// ParseVariables reads variables in the given format. The dotenv format
+// accepts the output of both the dotenv and shell formats: comments, empty
+// lines, export prefixes, and values made of unquoted (up to a comment or
+// the end of the line, blanks included), single quoted (as is) and double
+// quoted (with \n, \r, \t, \", \\, \$ and \` escapes) parts.
+func ParseVariables(data []byte, format string) (map[string]string, error) {
+	variables := map[string]string{}
+
+	switch format {
+	case EnvFormatJSON:
+		if err := json.Unmarshal(data, &variables); err != nil {
+			return nil, fmt.Errorf("Invalid JSON environment: %s", err)
+		}
+	case EnvFormatYAML:
+		if err := yaml.Unmarshal(data, &variables); err != nil {
+			return nil, fmt.Errorf("Invalid YAML environment: %s", err)
+		}
+	case EnvFormatDotenv, EnvFormatShell:
+		p := dotenvParser{data: []rune(string(data)), line: 1}
+		if err := p.parse(variables); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("Unknown format: %s. Correct values are %s", format, strings.Join(EnvFormats, ", "))
+	}
+
+	for key := range variables {
+		if !ValidVariableName(key) {
+			return nil, fmt.Errorf("Invalid variable name '%s'", key)
+		}
+	}
+	return variables, nil
+}
+
+type dotenvParser struct {
+	data []rune
+	pos  int
+	line int
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *dotenvParser) next() rune {
+	r := p.data[p.pos]
+	p.pos++
+	if r == '\n' {
+		p.line++
+	}
+	return r
+}
+
+func (p *dotenvParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("Line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *dotenvParser) skipBlanks() {
+	for !p.eof() && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipLine skips the rest of the line, which must be empty or a comment
+func (p *dotenvParser) skipLine() error {
+	p.skipBlanks()
+	if !p.eof() && p.data[p.pos] == '#' {
+		for !p.eof() && p.data[p.pos] != '\n' {
+			p.pos++
+		}
+	}
+	if p.eof() {
+		return nil
+	}
+	switch r := p.next(); r {
+	case '\n':
+		return nil
+	case '\r':
+		if !p.eof() && p.data[p.pos] == '\n' {
+			p.next()
+			return nil
+		}
+	}
+	return p.errorf("Unexpected characters after the value")
+}
+
+func (p *dotenvParser) parse(variables map[string]string) error {
+	for !p.eof() {
+		p.skipBlanks()
+		if p.eof() {
+			break
+		}
+		if r := p.data[p.pos]; r == '#' || r == '\n' || r == '\r' {
+			if err := p.skipLine(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		start := p.pos
+		for !p.eof() && p.data[p.pos] != '=' && p.data[p.pos] != '\n' {
+			p.pos++
+		}
+		key := strings.TrimSpace(string(p.data[start:p.pos]))
+		if strings.HasPrefix(key, "export ") || strings.HasPrefix(key, "export\t") {
+			key = strings.TrimSpace(key[len("export"):])
+		}
+		if p.eof() || p.data[p.pos] != '=' {
+			return p.errorf("Expected KEY=value, got '%s'", key)
+		}
+		if !ValidVariableName(key) {
+			return p.errorf("Invalid variable name '%s'", key)
+		}
+		p.pos++
+
+		p.skipBlanks()
+		value, err := p.value()
+		if err != nil {
+			return err
+		}
+		variables[key] = value
+
+		if err := p.skipLine(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// value reads quoted and unquoted parts up to a comment or the end of the
+// line, the blanks before them being trimmed
+func (p *dotenvParser) value() (string, error) {
+	var value bytes.Buffer
+	for !p.eof() {
+		switch r := p.data[p.pos]; r {
+		case '\'':
+			p.next()
+			closed := false
+			for !p.eof() {
+				if r := p.next(); r == '\'' {
+					closed = true
+					break
+				} else {
+					value.WriteRune(r)
+				}
+			}
+			if !closed {
+				return "", p.errorf("Unterminated single quoted value")
+			}
+		case '"':
+			if err := p.doubleQuoted(&value); err != nil {
+				return "", err
+			}
+		case '\\':
+			p.next()
+			if !p.eof() && p.data[p.pos] != '\n' {
+				value.WriteRune(p.next())
+			}
+		case ' ', '\t':
+			end := p.pos
+			for end < len(p.data) && (p.data[end] == ' ' || p.data[end] == '\t') {
+				end++
+			}
+			if end == len(p.data) || p.data[end] == '#' || p.data[end] == '\n' || p.data[end] == '\r' {
+				return value.String(), nil
+			}
+			value.WriteString(string(p.data[p.pos:end]))
+			p.pos = end
+		case '\n', '\r':
+			return value.String(), nil
+		case '#':
+			if value.Len() == 0 {
+				return "", nil
+			}
+			value.WriteRune(p.next())
+		default:
+			value.WriteRune(p.next())
+		}
+	}
+	return value.String(), nil
+}
+
+func (p *dotenvParser) doubleQuoted(value *bytes.Buffer) error {
+	escapes := map[rune]rune{'n': '\n', 'r': '\r', 't': '\t', '"': '"', '\\': '\\', '$': '$', '`': '`'}
+
+	p.next()
+	for !p.eof() {
+		r := p.next()
+		switch r {
+		case '"':
+			return nil
+		case '\\':
+			if p.eof() {
+				continue
+			}
+			if escaped, ok := escapes[p.data[p.pos]]; ok {
+				p.next()
+				value.WriteRune(escaped)
+				continue
+			}
+			value.WriteRune(r)
+		default:
+			value.WriteRune(r)
+		}
+	}
+	return p.errorf("Unterminated double quoted value")
+}
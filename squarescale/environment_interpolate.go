@@ -0,0 +1,152 @@
+package squarescale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// localEnvPrefix prefixes the references to the local process environment
+const localEnvPrefix = "env:"
+
+// Values returns all the variables of the VariableGroup, custom variables
+// overriding predefined ones.
+func (vg *VariableGroup) Values() map[string]string {
+	values := map[string]string{}
+	for _, variable := range vg.Variables {
+		if _, ok := values[variable.Key]; !ok || !variable.Predefined {
+			values[variable.Key] = variable.Value
+		}
+	}
+	return values
+}
+
+// Interpolate resolves the references of the values being set:
+// - ${KEY} is replaced by the value of KEY, taken from values, itself
+// resolved, or from scope, the variables already set, as is
+// - ${env:KEY} is replaced by the value of KEY in the local environment,
+// given by lookupEnv
+// - $${ stands for a literal ${
+// Cycles and undefined references are reported as errors.
+func Interpolate(values, scope map[string]string, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	i := interpolator{
+		values:    values,
+		scope:     scope,
+		lookupEnv: lookupEnv,
+		resolved:  make(map[string]string, len(values)),
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := i.resolve(key); err != nil {
+			return nil, err
+		}
+	}
+	return i.resolved, nil
+}
+
+// InterpolatedFormat tells whether the references of the values read from a
+// file in the given format are resolved: only the dotenv values are, the JSON
+// and YAML values being data and the shell ones being single quoted
+func InterpolatedFormat(format string) bool {
+	return format == EnvFormatDotenv
+}
+
+// EscapeReferences returns a copy of the variables whose ${ are written $${,
+// so that Interpolate gives back the values as is
+func EscapeReferences(variables map[string]string) map[string]string {
+	escaped := make(map[string]string, len(variables))
+	for key, value := range variables {
+		escaped[key] = strings.ReplaceAll(value, "${", "$${")
+	}
+	return escaped
+}
+
+type interpolator struct {
+	values    map[string]string
+	scope     map[string]string
+	lookupEnv func(string) (string, bool)
+	resolved  map[string]string
+	path      []string
+}
+
+func (i *interpolator) resolve(key string) (string, error) {
+	if value, ok := i.resolved[key]; ok {
+		return value, nil
+	}
+
+	for n, k := range i.path {
+		if k == key {
+			cycle := append(append([]string{}, i.path[n:]...), key)
+			return "", fmt.Errorf("Cycle in variable references: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	i.path = append(i.path, key)
+	value, err := i.expand(key, i.values[key])
+	i.path = i.path[:len(i.path)-1]
+	if err != nil {
+		return "", err
+	}
+
+	i.resolved[key] = value
+	return value, nil
+}
+
+func (i *interpolator) expand(key, value string) (string, error) {
+	var expanded strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			expanded.WriteString(value)
+			return expanded.String(), nil
+		}
+
+		if start > 0 && value[start-1] == '$' {
+			expanded.WriteString(value[:start-1] + "${")
+			value = value[start+2:]
+			continue
+		}
+
+		end := strings.Index(value[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("Unterminated reference in variable '%s'", key)
+		}
+
+		reference, err := i.reference(key, value[start+2:start+end])
+		if err != nil {
+			return "", err
+		}
+		expanded.WriteString(value[:start] + reference)
+		value = value[start+end+1:]
+	}
+}
+
+func (i *interpolator) reference(key, name string) (string, error) {
+	if strings.HasPrefix(name, localEnvPrefix) {
+		local := strings.TrimPrefix(name, localEnvPrefix)
+		if !ValidVariableName(local) {
+			return "", fmt.Errorf("Invalid reference '${%s}' in variable '%s'", name, key)
+		}
+		if value, ok := i.lookupEnv(local); ok {
+			return value, nil
+		}
+		return "", fmt.Errorf("Undefined local environment variable '%s' referenced by '%s'", local, key)
+	}
+
+	if !ValidVariableName(name) {
+		return "", fmt.Errorf("Invalid reference '${%s}' in variable '%s'", name, key)
+	}
+	if _, ok := i.values[name]; ok {
+		return i.resolve(name)
+	}
+	if value, ok := i.scope[name]; ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("Undefined variable '%s' referenced by '%s'", name, key)
+}
@@ -0,0 +1,117 @@
+package squarescale_test
+
+import (
+	. "github.com/squarescale/squarescale-cli/squarescale"
+)
+
+var _ = Describe("Environment interpolation", func() {
+	scope := map[string]string{"DB_HOST": "db.internal", "DB_PORT": "5432"}
+	lookupEnv := func(key string) (string, bool) {
+		if key == "CI_SHA" {
+			return "abc123", true
+		}
+		return "", false
+	}
+
+	Describe("Interpolate", func() {
+		It("resolves references to values, scope and local environment", func() {
+			values := map[string]string{
+				"DATABASE_URL": "postgres://${DB_HOST}:${DB_PORT}/${DB_NAME}",
+				"DB_NAME":      "app_${APP_ENV}",
+				"APP_ENV":      "staging",
+				"RELEASE":      "${env:CI_SHA}",
+				"LITERAL":      "$${DB_HOST} and pa$$word",
+			}
+
+			resolved, err := Interpolate(values, scope, lookupEnv)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(Equal(map[string]string{
+				"DATABASE_URL": "postgres://db.internal:5432/app_staging",
+				"DB_NAME":      "app_staging",
+				"APP_ENV":      "staging",
+				"RELEASE":      "abc123",
+				"LITERAL":      "${DB_HOST} and pa$$word",
+			}))
+		})
+
+		It("prefers the values being set to the scope", func() {
+			resolved, err := Interpolate(map[string]string{"DB_HOST": "other", "URL": "${DB_HOST}"}, scope, lookupEnv)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved["URL"]).To(Equal("other"))
+		})
+
+		It("reports cycles", func() {
+			_, err := Interpolate(map[string]string{"A": "${B}", "B": "x${C}", "C": "${A}"}, scope, lookupEnv)
+			Expect(err).To(MatchError("Cycle in variable references: A -> B -> C -> A"))
+
+			_, err = Interpolate(map[string]string{"A": "${A}"}, scope, lookupEnv)
+			Expect(err).To(MatchError("Cycle in variable references: A -> A"))
+		})
+
+		It("reports undefined and invalid references", func() {
+			_, err := Interpolate(map[string]string{"A": "${MISSING}"}, scope, lookupEnv)
+			Expect(err).To(MatchError("Undefined variable 'MISSING' referenced by 'A'"))
+
+			_, err = Interpolate(map[string]string{"A": "${env:MISSING}"}, scope, lookupEnv)
+			Expect(err).To(MatchError("Undefined local environment variable 'MISSING' referenced by 'A'"))
+
+			_, err = Interpolate(map[string]string{"A": "${not valid}"}, scope, lookupEnv)
+			Expect(err).To(MatchError("Invalid reference '${not valid}' in variable 'A'"))
+
+			_, err = Interpolate(map[string]string{"A": "${DB_HOST"}, scope, lookupEnv)
+			Expect(err).To(MatchError("Unterminated reference in variable 'A'"))
+		})
+	})
+
+	Describe("EscapeReferences", func() {
+		values := map[string]string{
+			"TEMPLATE": "Hello ${USER}, $${not} ${",
+			"PLAIN":    "no reference",
+		}
+
+		It("gives back the exported values once imported", func() {
+			for _, format := range EnvFormats {
+				exported := values
+				if InterpolatedFormat(format) {
+					exported = EscapeReferences(values)
+				}
+				formatted, err := FormatVariables(exported, format)
+				Expect(err).NotTo(HaveOccurred())
+
+				imported, err := ParseVariables([]byte(formatted), format)
+				Expect(err).NotTo(HaveOccurred())
+				if InterpolatedFormat(format) {
+					imported, err = Interpolate(imported, scope, lookupEnv)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(imported).To(Equal(values), format)
+			}
+		})
+
+		It("leaves the JSON values as is", func() {
+			Expect(InterpolatedFormat(EnvFormatJSON)).To(BeFalse())
+
+			formatted, err := FormatVariables(values, EnvFormatJSON)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(formatted).To(ContainSubstring(`"Hello ${USER}, $${not} ${"`))
+
+			imported, err := ParseVariables([]byte(formatted), EnvFormatJSON)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(Equal(values))
+		})
+	})
+
+	Describe("Values", func() {
+		It("returns custom variables over predefined ones", func() {
+			group := &VariableGroup{Variables: []*Variable{
+				{Key: "DB_NAME", Value: "predefined", Predefined: true},
+				{Key: "DB_NAME", Value: "custom"},
+				{Key: "DB_HOST", Value: "db", Predefined: true},
+			}}
+
+			Expect(group.Values()).To(Equal(map[string]string{"DB_NAME": "custom", "DB_HOST": "db"}))
+		})
+	})
+})
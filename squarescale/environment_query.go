@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/olekukonko/tablewriter"
+	"github.com/squarescale/squarescale-cli/redact"
 	"strings"
 )
 
@@ -12,6 +13,7 @@ type QueryOptions struct {
 	DisplayAll   bool
 	ServiceName  string
 	VariableName string
+	Reveal       bool
 }
 
 // QueryResult is an interface for objects returned by QueryVars when filtering
@@ -24,7 +26,12 @@ type QueryResult interface {
 // QueryVars filters environment variables based on the given QueryOptions.
 // It returns a QueryResult which can then be printed.
 // If a requested service or variable don't exist, an error is returned.
+// Secret values are redacted unless Reveal is set.
 func (env *Environment) QueryVars(options QueryOptions) (QueryResult, error) {
+	if !options.Reveal {
+		env = env.Redacted()
+	}
+
 	if options.DisplayAll {
 		return env, nil
 	}
@@ -53,6 +60,34 @@ func (env *Environment) QueryVars(options QueryOptions) (QueryResult, error) {
 	return result, nil
 }
 
+// Redacted returns a copy of the Environment whose secret values are
+// redacted.
+func (env *Environment) Redacted() *Environment {
+	redacted := &Environment{Project: env.Project.Redacted()}
+	for _, vg := range env.Services {
+		redacted.Services = append(redacted.Services, vg.Redacted())
+	}
+	return redacted
+}
+
+// Redacted returns a copy of the VariableGroup whose secret values are
+// redacted.
+func (vg *VariableGroup) Redacted() *VariableGroup {
+	if vg == nil {
+		return nil
+	}
+
+	redacted := &VariableGroup{Name: vg.Name, Variables: make([]*Variable, 0, len(vg.Variables))}
+	for _, v := range vg.Variables {
+		redacted.Variables = append(redacted.Variables, &Variable{
+			Key:        v.Key,
+			Value:      redact.Value(v.Key, v.Value),
+			Predefined: v.Predefined,
+		})
+	}
+	return redacted
+}
+
 // String returns a pretty string representation of the Project and Services
 // variables with a title for each.
 func (env *Environment) String() string {
@@ -94,6 +94,32 @@ var _ = Describe("Environment", func() {
 			})
 		})
 
+		Context("when the environment holds secrets", func() {
+			BeforeEach(func() {
+				environment.Project.Variables = append(environment.Project.Variables,
+					&Variable{Key: "DB_PASSWORD", Value: "s3cr3t", Predefined: true},
+					&Variable{Key: "DATABASE_URL", Value: "postgres://user:s3cr3t@db/app"},
+				)
+			})
+
+			It("redacts them", func() {
+				Expect(result.String()).NotTo(ContainSubstring("s3cr3t"))
+				Expect(result.String()).To(ContainSubstring("DB_PASSWORD=<redacted>"))
+				Expect(result.String()).To(ContainSubstring("DATABASE_URL=postgres://user:<redacted>@db/app"))
+				Expect(environment.Project.Variables[2].Value).To(Equal("s3cr3t"))
+			})
+
+			Context("when option Reveal is set to true", func() {
+				BeforeEach(func() {
+					options.Reveal = true
+				})
+
+				It("returns them as is", func() {
+					Expect(result.String()).To(ContainSubstring("DB_PASSWORD=s3cr3t"))
+				})
+			})
+		})
+
 		Context("when option DisplayAll is set to false", func() {
 			var (
 				expectedVariableGroup *VariableGroup
@@ -0,0 +1,134 @@
+package squarescale
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// APIError describes an error returned by the SquareScale API
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Method and Path identify the API call
+	Method string
+	Path   string
+	// Message describes the error to the user
+	Message string
+	// ServerMessage is the error message sent by the server, if any
+	ServerMessage string
+	// FieldErrors are the validation errors sent by the server, by field
+	FieldErrors map[string][]string
+	// RequestID is the identifier of the request on the server side, if any
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// IsNotFound tells whether err is an API error caused by a missing resource
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict tells whether err is an API error caused by an already existing resource
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized tells whether err is an API error caused by a missing or invalid token
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsValidation tells whether err is an API error caused by invalid values
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+func hasStatus(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+// errorf returns an *APIError for the response described by the given message
+func (r *response) errorf(format string, args ...interface{}) error {
+	apiErr := &APIError{
+		StatusCode: r.code,
+		Method:     r.method,
+		Path:       r.path,
+		Message:    fmt.Sprintf(format, args...),
+	}
+
+	if r.header != nil {
+		apiErr.RequestID = r.header.Get("X-Request-Id")
+	}
+
+	var data map[string]interface{}
+	if json.Unmarshal(r.body, &data) == nil {
+		if message, ok := data["error"].(string); ok {
+			apiErr.ServerMessage = message
+		}
+		if nested, ok := data["errors"].(map[string]interface{}); ok {
+			data = nested
+		}
+		fields := map[string][]string{}
+		collectFieldErrors(data, "error", "", fields)
+		if len(fields) > 0 {
+			apiErr.FieldErrors = fields
+		}
+	}
+
+	return apiErr
+}
+
+// notFoundError returns the *APIError of a resource missing from the result
+// of the GET of path, detected on the client side
+func notFoundError(path, format string, args ...interface{}) error {
+	return &APIError{
+		StatusCode: http.StatusNotFound,
+		Method:     "GET",
+		Path:       path,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+// unexpectedError returns an *APIError for a response the caller does not
+// handle, described from its body when possible
+func (r *response) unexpectedError() error {
+	return r.errorf("%s", unexpectedHTTPError(r.code, r.body))
+}
+
+// collectFieldErrors flattens the validation errors of a response body, nested
+// field names being joined with a space like decodeAnyError does
+func collectFieldErrors(errs map[string]interface{}, exclude, prefix string, fields map[string][]string) {
+	keys := make([]string, 0, len(errs))
+	for k := range errs {
+		if k != exclude {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		name := k
+		if prefix != "" {
+			name = prefix + " " + k
+		}
+		switch val := errs[k].(type) {
+		case string:
+			fields[name] = append(fields[name], val)
+		case []interface{}:
+			for _, value := range val {
+				if s, ok := value.(string); ok {
+					fields[name] = append(fields[name], s)
+				}
+			}
+		case map[string]interface{}:
+			collectFieldErrors(val, "", name, fields)
+		}
+	}
+}
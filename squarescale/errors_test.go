@@ -0,0 +1,256 @@
+package squarescale_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Run("Test not found API error", NotFoundAPIError)
+	t.Run("Test conflict API error", ConflictAPIError)
+	t.Run("Test unauthorized API error", UnauthorizedAPIError)
+	t.Run("Test validation API error", ValidationAPIError)
+	t.Run("Test unknown project name is not found", UnknownProjectNameIsNotFound)
+	t.Run("Test unknown service name is not found", UnknownServiceNameIsNotFound)
+	t.Run("Test unknown project on logs is not found", UnknownProjectOnLogsIsNotFound)
+	t.Run("Test unknown environment group and variable are not found", UnknownEnvironmentEntryIsNotFound)
+	t.Run("Test unknown external node on download is not found", UnknownExternalNodeOnDownloadIsNotFound)
+}
+
+func NotFoundAPIError(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "5a1c3d6e")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "Couldn't find Project"}`))
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, err := cli.GetProject("8cfe8f68-cad5-4157-b8a6-d9efa12caf0e")
+
+	// then
+	expectedError := "Project '8cfe8f68-cad5-4157-b8a6-d9efa12caf0e' not found"
+	if fmt.Sprintf("%s", err) != expectedError {
+		t.Fatalf("Expect error message `%s`, got `%s`", expectedError, err)
+	}
+
+	var apiErr *squarescale.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expect an APIError, got `%T`", err)
+	}
+
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expect status code `%d`, got `%d`", http.StatusNotFound, apiErr.StatusCode)
+	}
+
+	if apiErr.Method != "GET" || apiErr.Path != "/projects/8cfe8f68-cad5-4157-b8a6-d9efa12caf0e" {
+		t.Errorf("Expect request `GET /projects/8cfe8f68-cad5-4157-b8a6-d9efa12caf0e`, got `%s %s`", apiErr.Method, apiErr.Path)
+	}
+
+	if apiErr.RequestID != "5a1c3d6e" {
+		t.Errorf("Expect request id `5a1c3d6e`, got `%s`", apiErr.RequestID)
+	}
+
+	if apiErr.ServerMessage != "Couldn't find Project" {
+		t.Errorf("Expect server message `Couldn't find Project`, got `%s`", apiErr.ServerMessage)
+	}
+
+	if !squarescale.IsNotFound(err) || squarescale.IsConflict(err) || squarescale.IsUnauthorized(err) {
+		t.Errorf("Expect error to only be a not found error")
+	}
+}
+
+func ConflictAPIError(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error": "Service already exists"}`))
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, err := cli.CreateProject(&squarescale.JSONObject{"name": "project-test", "credential_name": "aws-production"})
+
+	// then
+	if !squarescale.IsConflict(err) {
+		t.Fatalf("Expect a conflict error, got `%v`", err)
+	}
+
+	if squarescale.IsNotFound(err) {
+		t.Errorf("Expect error not to be a not found error")
+	}
+}
+
+func UnauthorizedAPIError(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, err := cli.ListProjects()
+
+	// then
+	if !squarescale.IsUnauthorized(err) {
+		t.Fatalf("Expect an unauthorized error, got `%v`", err)
+	}
+}
+
+func ValidationAPIError(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error": "Validation failed", "errors": {"name": ["is too short", "is invalid"], "infra": {"size": "must be positive"}}}`))
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, err := cli.CreateProject(&squarescale.JSONObject{"name": "p", "credential_name": "aws-production"})
+
+	// then
+	if !squarescale.IsValidation(err) {
+		t.Fatalf("Expect a validation error, got `%v`", err)
+	}
+
+	var apiErr *squarescale.APIError
+	errors.As(err, &apiErr)
+
+	if apiErr.Method != "POST" || apiErr.Path != "/projects" {
+		t.Errorf("Expect request `POST /projects`, got `%s %s`", apiErr.Method, apiErr.Path)
+	}
+
+	if fmt.Sprint(apiErr.FieldErrors["name"]) != "[is too short is invalid]" {
+		t.Errorf("Expect name errors `[is too short is invalid]`, got `%v`", apiErr.FieldErrors["name"])
+	}
+
+	if fmt.Sprint(apiErr.FieldErrors["infra size"]) != "[must be positive]" {
+		t.Errorf("Expect infra size errors `[must be positive]`, got `%v`", apiErr.FieldErrors["infra size"])
+	}
+}
+
+func UnknownProjectNameIsNotFound(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, err := cli.ProjectByName("unknown")
+
+	// then
+	if !squarescale.IsNotFound(err) {
+		t.Fatalf("Expect a not found error, got `%v`", err)
+	}
+
+	expectedError := "Project 'unknown' not found"
+	if fmt.Sprintf("%s", err) != expectedError {
+		t.Fatalf("Expect error message `%s`, got `%s`", expectedError, err)
+	}
+}
+
+func UnknownServiceNameIsNotFound(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name": "web"}]`))
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, err := cli.GetServiceInfo("project-uuid", "unknown")
+
+	// then
+	if !squarescale.IsNotFound(err) {
+		t.Fatalf("Expect a not found error, got `%v`", err)
+	}
+
+	expectedError := "Service 'unknown' not found for project 'project-uuid'"
+	if fmt.Sprintf("%s", err) != expectedError {
+		t.Fatalf("Expect error message `%s`, got `%s`", expectedError, err)
+	}
+}
+
+func UnknownProjectOnLogsIsNotFound(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "Bad request"}`))
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, _, err := cli.ProjectLogs("unknown", "web", "")
+
+	// then
+	if !squarescale.IsNotFound(err) {
+		t.Fatalf("Expect a not found error, got `%v`", err)
+	}
+
+	expectedError := "Project 'unknown' not found"
+	if fmt.Sprintf("%s", err) != expectedError {
+		t.Fatalf("Expect error message `%s`, got `%s`", expectedError, err)
+	}
+}
+
+func UnknownEnvironmentEntryIsNotFound(t *testing.T) {
+	// given
+	env := &squarescale.Environment{
+		Project:  &squarescale.VariableGroup{Name: "Project"},
+		Services: []*squarescale.VariableGroup{{Name: "web"}},
+	}
+
+	// when
+	_, groupErr := env.GetServiceGroup("unknown")
+	_, variableErr := env.Services[0].GetVariable("UNKNOWN")
+
+	// then
+	if !squarescale.IsNotFound(groupErr) {
+		t.Errorf("Expect a not found error for the group, got `%v`", groupErr)
+	}
+	if !squarescale.IsNotFound(variableErr) {
+		t.Errorf("Expect a not found error for the variable, got `%v`", variableErr)
+	}
+
+	expectedError := "Could not find variable 'UNKNOWN' for container 'web'"
+	if fmt.Sprintf("%s", variableErr) != expectedError {
+		t.Fatalf("Expect error message `%s`, got `%s`", expectedError, variableErr)
+	}
+}
+
+func UnknownExternalNodeOnDownloadIsNotFound(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": 1, "name": "node"}]`))
+	}))
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	err := cli.DownloadConfigExternalNode("project-uuid", "unknown", "all")
+
+	// then
+	if !squarescale.IsNotFound(err) {
+		t.Fatalf("Expect a not found error, got `%v`", err)
+	}
+}
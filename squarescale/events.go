@@ -0,0 +1,242 @@
+package squarescale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/squarescale/logger"
+	"github.com/squarescale/squarescale-cli/cable"
+)
+
+// ActionCable channels streaming project events
+const (
+	ProjectChannel       = "ProjectChannel"
+	ServicesChannel      = "ServicesChannel"
+	NotificationsChannel = "NotificationsChannel"
+)
+
+// EventChannels lists the channels a project can be watched on
+var EventChannels = []string{ProjectChannel, ServicesChannel, NotificationsChannel}
+
+// Event types carried by the event channels
+const (
+	InfraStatusEventType     = "infra_status"
+	ServiceScheduleEventType = "service_schedule"
+	NotificationEventType    = "notification"
+)
+
+// InfraStatusEvent describes a change of the project infrastructure status
+type InfraStatusEvent struct {
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status"`
+}
+
+// ServiceScheduleEvent describes a scheduling update of a project service
+type ServiceScheduleEvent struct {
+	Service string `json:"service"`
+	Status  string `json:"status"`
+	Running int    `json:"running"`
+	Desired int    `json:"desired"`
+}
+
+// NotificationEvent describes a notification sent to the project members
+type NotificationEvent struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// Event is a real-time event received on a project channel. Depending on its
+// type, one of InfraStatus, ServiceSchedule or Notification is set; Raw always
+// holds the message as sent by the server.
+type Event struct {
+	Channel         string
+	Type            string
+	Timestamp       string
+	InfraStatus     *InfraStatusEvent
+	ServiceSchedule *ServiceScheduleEvent
+	Notification    *NotificationEvent
+	Raw             json.RawMessage
+}
+
+// Time returns the event timestamp parsed as RFC3339
+func (e *Event) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, e.Timestamp)
+}
+
+// Subscription is a live subscription to a project channel returned by
+// Subscribe, it holds its own connection which is reopened when lost
+type Subscription struct {
+	// Events delivers the channel events, it is closed when the subscription ends
+	Events <-chan Event
+
+	channel    string
+	identifier string
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{}
+	mu         sync.Mutex
+	err        error
+}
+
+// Err returns the error which ended the subscription, if any
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Subscription) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Close stops the subscription, closes its connection and then the Events
+// channel
+func (s *Subscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Subscribe listens to the events of a project on the given channel until
+// the subscription or the client context is closed
+func (c *Client) Subscribe(projectUUID, channel string) (*Subscription, error) {
+	identifier, err := json.Marshal(map[string]string{
+		"channel": channel,
+		"project": projectUUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event, 32)
+	ctx, cancel := context.WithCancel(c.Context())
+	s := &Subscription{
+		Events:     events,
+		channel:    channel,
+		identifier: string(identifier),
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	if !c.subscriptions.add(s) {
+		cancel()
+		return nil, fmt.Errorf("Already subscribed to %s of project %s", channel, projectUUID)
+	}
+	go s.run(c, events)
+
+	return s, nil
+}
+
+// Close ends the subscriptions opened by Subscribe, on this client and the
+// copies made by WithContext
+func (c *Client) Close() {
+	for _, s := range c.subscriptions.list() {
+		s.Close()
+	}
+}
+
+// run follows the channel until the subscription context is done or the
+// server ends the subscription, then closes Events
+func (s *Subscription) run(c *Client, events chan<- Event) {
+	defer close(s.done)
+	defer close(events)
+	defer c.subscriptions.remove(s)
+
+	sub := cable.Subscription{
+		URL:        strings.Replace(c.endpoint, "http", "ws", 1) + "/cable",
+		Header:     c.cableHeaders(),
+		Identifier: s.identifier,
+	}
+	err := cable.Run(s.ctx, sub, func(message json.RawMessage) {
+		select {
+		case events <- decodeEvent(s.channel, message):
+		case <-s.ctx.Done():
+		}
+	})
+	s.setErr(err)
+}
+
+func (c *Client) cableHeaders() http.Header {
+	h := make(http.Header, 3)
+	h.Set("Authorization", "bearer "+c.token)
+	h.Set("API-Version", supportedAPI)
+	h.Set("Origin", c.endpoint)
+	return h
+}
+
+// subscriptions tracks the live subscriptions of a client by identifier, it
+// is shared by the copies of the client made by WithContext
+type subscriptions struct {
+	mu   sync.Mutex
+	byID map[string]*Subscription
+}
+
+func (r *subscriptions) add(s *Subscription) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[s.identifier]; ok {
+		return false
+	}
+	if r.byID == nil {
+		r.byID = map[string]*Subscription{}
+	}
+	r.byID[s.identifier] = s
+	return true
+}
+
+func (r *subscriptions) remove(s *Subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byID[s.identifier] == s {
+		delete(r.byID, s.identifier)
+	}
+}
+
+func (r *subscriptions) list() []*Subscription {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := make([]*Subscription, 0, len(r.byID))
+	for _, s := range r.byID {
+		list = append(list, s)
+	}
+	return list
+}
+
+func decodeEvent(channel string, message json.RawMessage) Event {
+	event := Event{Channel: channel, Raw: message}
+
+	var header struct {
+		Type      string `json:"type"`
+		Timestamp string `json:"timestamp"`
+	}
+	err := json.Unmarshal(message, &header)
+	if err != nil {
+		logger.Debug.Printf("Undecodable event on %s: %s", channel, err)
+		return event
+	}
+	event.Type = header.Type
+	event.Timestamp = header.Timestamp
+
+	switch event.Type {
+	case InfraStatusEventType:
+		event.InfraStatus = &InfraStatusEvent{}
+		err = json.Unmarshal(message, event.InfraStatus)
+	case ServiceScheduleEventType:
+		event.ServiceSchedule = &ServiceScheduleEvent{}
+		err = json.Unmarshal(message, event.ServiceSchedule)
+	case NotificationEventType:
+		event.Notification = &NotificationEvent{}
+		err = json.Unmarshal(message, event.Notification)
+	}
+	if err != nil {
+		logger.Debug.Printf("Undecodable %s event on %s: %s", event.Type, channel, err)
+	}
+
+	return event
+}
@@ -0,0 +1,336 @@
+package squarescale_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+// otherProjectUUID is a second project the cable server accepts subscriptions on
+const otherProjectUUID = "8d4e2f0a-7c1b-4e5d-9a36-2b7f1c0e9d58"
+
+func TestSubscribe(t *testing.T) {
+	t.Run("Nominal case on Subscribe", nominalCaseOnSubscribe)
+	t.Run("Test rejected subscription on Subscribe", RejectedSubscriptionOnSubscribe)
+	t.Run("Test Close with unread events on Subscribe", closeWithUnreadEventsOnSubscribe)
+	t.Run("Test client context cancellation on Subscribe", clientContextOnSubscribe)
+	t.Run("Test same channel of two projects on Subscribe", sameChannelOfTwoProjectsOnSubscribe)
+	t.Run("Test copies of the client on Subscribe", clientCopiesOnSubscribe)
+}
+
+// cableServer is a minimal ActionCable stand-in: it greets the client,
+// answers the first subscribe command with reply and then sends messages on
+// the subscribed identifier.
+func cableServer(t *testing.T, token string, reply string, messages []string) *httptest.Server {
+	return httptest.NewServer(cableHandler(t, token, reply, messages))
+}
+
+func cableHandler(t *testing.T, token string, reply string, messages []string) http.HandlerFunc {
+	upgrader := websocket.Upgrader{}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		checkPath(t, "/cable", r.URL.Path)
+		checkAuthorization(t, r.Header.Get("Authorization"), token)
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Websocket upgrade failed: %s", err)
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]string{"type": "welcome"})
+
+		var command struct {
+			Command    string `json:"command"`
+			Identifier string `json:"identifier"`
+		}
+		if err := conn.ReadJSON(&command); err != nil {
+			return
+		}
+		if command.Command != "subscribe" {
+			t.Errorf("Expect subscribe command, got `%s`", command.Command)
+			return
+		}
+
+		var identifier map[string]string
+		json.Unmarshal([]byte(command.Identifier), &identifier)
+		if project := identifier["project"]; project != "5fb75c1d-90a4-4b34-891f-a7481fa04afe" && project != otherProjectUUID {
+			t.Errorf("Expect project identifier, got `%s`", identifier["project"])
+		}
+
+		conn.WriteJSON(map[string]string{"type": reply, "identifier": command.Identifier})
+		for _, message := range messages {
+			conn.WriteJSON(map[string]interface{}{
+				"identifier": command.Identifier,
+				"message":    json.RawMessage(message),
+			})
+		}
+
+		// keep the connection open until the client leaves
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func nextEvent(t *testing.T, sub *squarescale.Subscription) squarescale.Event {
+	select {
+	case event, ok := <-sub.Events:
+		if !ok {
+			t.Fatalf("Events channel closed: %v", sub.Err())
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout waiting for an event")
+	}
+	return squarescale.Event{}
+}
+
+func nominalCaseOnSubscribe(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	server := cableServer(t, token, "confirm_subscription", []string{
+		`{"type": "infra_status", "timestamp": "2023-08-17T10:00:01Z", "status": "ok", "previous_status": "provisionning"}`,
+		`{"type": "service_schedule", "service": "web", "status": "running", "running": 2, "desired": 3}`,
+		`{"type": "notification", "level": "warning", "message": "certificate expires soon"}`,
+		`{"type": "unknown", "foo": "bar"}`,
+	})
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	defer cli.Close()
+
+	// when
+	sub, err := cli.Subscribe(projectUUID, squarescale.ProjectChannel)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	event := nextEvent(t, sub)
+	if event.Channel != squarescale.ProjectChannel {
+		t.Fatalf("Expect event.Channel `%s`, got `%s`", squarescale.ProjectChannel, event.Channel)
+	}
+	if event.InfraStatus == nil || event.InfraStatus.Status != "ok" || event.InfraStatus.PreviousStatus != "provisionning" {
+		t.Fatalf("Expect infra status event `ok`, got `%+v`", event.InfraStatus)
+	}
+	if _, err := event.Time(); err != nil {
+		t.Fatalf("Expect no error parsing timestamp, got `%s`", err)
+	}
+
+	event = nextEvent(t, sub)
+	expected := squarescale.ServiceScheduleEvent{Service: "web", Status: "running", Running: 2, Desired: 3}
+	if event.ServiceSchedule == nil || *event.ServiceSchedule != expected {
+		t.Fatalf("Expect service schedule event `%+v`, got `%+v`", expected, event.ServiceSchedule)
+	}
+
+	event = nextEvent(t, sub)
+	if event.Notification == nil || event.Notification.Message != "certificate expires soon" {
+		t.Fatalf("Expect notification event, got `%+v`", event.Notification)
+	}
+
+	event = nextEvent(t, sub)
+	if event.Type != "unknown" || event.InfraStatus != nil || event.ServiceSchedule != nil || event.Notification != nil {
+		t.Fatalf("Expect untyped event, got `%+v`", event)
+	}
+	if string(event.Raw) != `{"type":"unknown","foo":"bar"}` {
+		t.Fatalf("Expect raw message to be kept, got `%s`", event.Raw)
+	}
+
+	sub.Close()
+	select {
+	case _, ok := <-sub.Events:
+		if ok {
+			t.Fatalf("Expect Events channel to be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout waiting for Events channel to be closed")
+	}
+}
+
+func RejectedSubscriptionOnSubscribe(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	server := cableServer(t, token, "reject_subscription", nil)
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	defer cli.Close()
+
+	// when
+	sub, err := cli.Subscribe(projectUUID, squarescale.ServicesChannel)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// then
+	select {
+	case _, ok := <-sub.Events:
+		if ok {
+			t.Fatalf("Expect no event on rejected subscription")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timeout waiting for subscription rejection")
+	}
+
+	expectedError := "subscription rejected"
+	if sub.Err() == nil || sub.Err().Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, sub.Err())
+	}
+}
+
+// waitClosed fails the test unless events is closed in time, without
+// expecting any event
+func waitClosed(t *testing.T, events <-chan squarescale.Event) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Timeout waiting for Events channel to be closed")
+		}
+	}
+}
+
+func closeWithUnreadEventsOnSubscribe(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	messages := make([]string, 100)
+	for i := range messages {
+		messages[i] = `{"type": "notification", "level": "info", "message": "hello"}`
+	}
+	left := make(chan struct{})
+	handler := cableHandler(t, token, "confirm_subscription", messages)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(left)
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	sub, err := cli.Subscribe(projectUUID, squarescale.NotificationsChannel)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+	nextEvent(t, sub)
+	time.Sleep(100 * time.Millisecond)
+
+	// when
+	cli.Close()
+
+	// then
+	select {
+	case <-left:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expect the websocket to be closed")
+	}
+	waitClosed(t, sub.Events)
+}
+
+func clientContextOnSubscribe(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	server := cableServer(t, token, "confirm_subscription", nil)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cli := squarescale.NewClient(server.URL, token).WithContext(ctx)
+	sub, err := cli.Subscribe(projectUUID, squarescale.ProjectChannel)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// when
+	cancel()
+
+	// then
+	waitClosed(t, sub.Events)
+	if sub.Err() != nil {
+		t.Fatalf("Expect no error, got `%s`", sub.Err())
+	}
+}
+
+func sameChannelOfTwoProjectsOnSubscribe(t *testing.T) {
+	// given
+	token := "some-token"
+	server := cableServer(t, token, "confirm_subscription", []string{
+		`{"type": "service_schedule", "service": "web", "status": "running", "running": 1, "desired": 1}`,
+	})
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	defer cli.Close()
+
+	first, err := cli.Subscribe("5fb75c1d-90a4-4b34-891f-a7481fa04afe", squarescale.ServicesChannel)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// when
+	second, err := cli.Subscribe(otherProjectUUID, squarescale.ServicesChannel)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error subscribing to another project, got `%s`", err)
+	}
+	nextEvent(t, second)
+	second.Close()
+
+	_, err = cli.Subscribe("5fb75c1d-90a4-4b34-891f-a7481fa04afe", squarescale.ServicesChannel)
+	expectedError := "Already subscribed to ServicesChannel of project 5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, err)
+	}
+
+	first.Close()
+	if _, err := cli.Subscribe("5fb75c1d-90a4-4b34-891f-a7481fa04afe", squarescale.ServicesChannel); err != nil {
+		t.Fatalf("Expect no error once the subscription is closed, got `%s`", err)
+	}
+}
+
+func clientCopiesOnSubscribe(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	server := cableServer(t, token, "confirm_subscription", nil)
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	sub, err := cli.WithContext(context.Background()).Subscribe(projectUUID, squarescale.ProjectChannel)
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	// when
+	_, err = cli.Subscribe(projectUUID, squarescale.ProjectChannel)
+
+	// then
+	if err == nil {
+		t.Fatalf("Expect the subscription of the copy to be known by the client")
+	}
+
+	cli.Close()
+	waitClosed(t, sub.Events)
+}
@@ -19,22 +19,22 @@ type ExternalNode struct {
 
 // GetExternalNodes gets all the external nodes attached to a Project
 func (c *Client) GetExternalNodes(projectUUID string) ([]ExternalNode, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/external_nodes")
+	res, err := c.get("/projects/" + projectUUID + "/external_nodes")
 	if err != nil {
 		return []ExternalNode{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []ExternalNode{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []ExternalNode{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []ExternalNode{}, unexpectedHTTPError(code, body)
+		return []ExternalNode{}, res.unexpectedError()
 	}
 
 	var externalNodesByID []ExternalNode
 
-	if err := json.Unmarshal(body, &externalNodesByID); err != nil {
+	if err := json.Unmarshal(res.body, &externalNodesByID); err != nil {
 		return []ExternalNode{}, err
 	}
 
@@ -54,7 +54,7 @@ func (c *Client) GetExternalNodeInfo(projectUUID string, name string) (ExternalN
 		}
 	}
 
-	return ExternalNode{}, fmt.Errorf("External node '%s' not found for project '%s'", name, projectUUID)
+	return ExternalNode{}, notFoundError("/projects/"+projectUUID+"/external_nodes", "External node '%s' not found for project '%s'", name, projectUUID)
 }
 
 // AddExternalNode add a new scheduling group
@@ -65,51 +65,55 @@ func (c *Client) AddExternalNode(projectUUID string, name string, public_ip stri
 		"name":      name,
 		"public_ip": public_ip,
 	}
-	code, body, err := c.post("/projects/"+projectUUID+"/external_nodes", &payload)
+	res, err := c.post("/projects/"+projectUUID+"/external_nodes", &payload)
 	if err != nil {
 		return newExternalNode, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 	case http.StatusNotFound:
-		return newExternalNode, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return newExternalNode, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return newExternalNode, unexpectedHTTPError(code, body)
+		return newExternalNode, res.unexpectedError()
 	}
 
-	if err := json.Unmarshal(body, &newExternalNode); err != nil {
+	if err := json.Unmarshal(res.body, &newExternalNode); err != nil {
 		return newExternalNode, err
 	}
 
 	return newExternalNode, nil
 }
 
-// TODO: potentially add a real timeout on this wait condition
-// WaitExternalNode wait a new external-node to reach one status
+// WaitExternalNode wait a new external-node to reach one status, until the
+// context of the client is done: WaitExternalNodeWithOptions sets a timeout
 func (c *Client) WaitExternalNode(projectUUID string, name string, timeToWait int64, targetStatuses []string) (ExternalNode, error) {
-	externalNode, err := c.GetExternalNodeInfo(projectUUID, name)
-	if err != nil {
-		return externalNode, err
-	}
+	opts := WaitOptions{Interval: time.Duration(timeToWait) * time.Second}
+	return c.WaitExternalNodeWithOptions(projectUUID, name, targetStatuses, opts)
+}
 
-	if len(targetStatuses) > 0 {
-		found := false
-		logger.Debug.Println("externalNode status: ", externalNode.Name, " Status: ", externalNode.Status)
-		for err == nil && !found {
-			for _, v := range targetStatuses {
-				if externalNode.Status == v {
-					found = true
-					break
-				}
-			}
-			if !found {
-				time.Sleep(time.Duration(timeToWait) * time.Second)
-				externalNode, err = c.GetExternalNodeInfo(projectUUID, name)
-				logger.Debug.Println("externalNode status update: ", externalNode.Name, " Status: ", externalNode.Status)
+// WaitExternalNodeWithOptions wait until the external node reaches one of the
+// target statuses, it returns right away when no target status is given
+func (c *Client) WaitExternalNodeWithOptions(projectUUID string, name string, targetStatuses []string, opts WaitOptions) (ExternalNode, error) {
+	var externalNode ExternalNode
+	_, err := c.Wait(projectUUID, opts, func(client *Client) (string, bool, error) {
+		var err error
+		externalNode, err = client.GetExternalNodeInfo(projectUUID, name)
+		if err != nil {
+			return "", false, err
+		}
+		logger.Debug.Println("externalNode status update: ", externalNode.Name, " Status: ", externalNode.Status)
+
+		if len(targetStatuses) == 0 {
+			return externalNode.Status, true, nil
+		}
+		for _, v := range targetStatuses {
+			if externalNode.Status == v {
+				return externalNode.Status, true, nil
 			}
 		}
-	}
+		return externalNode.Status, false, nil
+	})
 
 	return externalNode, err
 }
@@ -142,5 +146,5 @@ func (c *Client) DownloadConfigExternalNode(projectUUID, name, configName string
 			return nil
 		}
 	}
-	return fmt.Errorf("Unable to find external node '%s' in project '%s'", name, projectUUID)
+	return notFoundError("/projects/"+projectUUID+"/external_nodes", "Unable to find external node '%s' in project '%s'", name, projectUUID)
 }
@@ -1,10 +1,13 @@
 package squarescale_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/squarescale/squarescale-cli/squarescale"
 )
@@ -25,6 +28,7 @@ func TestExternalNodes(t *testing.T) {
 
 	// WaitExtraNode
 	t.Run("Nominal case on WaitExtraNode", nominalCaseOnWaitExternalNode)
+	t.Run("Test client context done on WaitExternalNode", contextDoneOnWaitExternalNode)
 
 	// Error cases
 	t.Run("Test HTTP client error on external-nodes methods (get, add, delete)", ClientHTTPErrorOnSchedulingGroupMethods)
@@ -400,3 +404,28 @@ func nominalCaseOnWaitExternalNode(t *testing.T) {
 		t.Fatalf("Expect no error, got `%s`", err)
 	}
 }
+
+func contextDoneOnWaitExternalNode(t *testing.T) {
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": 23, "name": "my-node", "status": "not_provisionned"}]`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	cli := squarescale.NewClient(server.URL, "some-token").WithContext(ctx)
+
+	// when
+	start := time.Now()
+	_, err := cli.WaitExternalNode("project-uuid", "my-node", 5, []string{"provisionned"})
+
+	// then
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expect the deadline of the client context, got `%v`", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expect the wait to stop with the client context, took %s", elapsed)
+	}
+}
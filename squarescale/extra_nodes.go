@@ -20,22 +20,22 @@ type ExtraNode struct {
 
 // GetExtraNodes gets all the extraNodes attached to a Project
 func (c *Client) GetExtraNodes(projectUUID string) ([]ExtraNode, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/statefull_nodes")
+	res, err := c.get("/projects/" + projectUUID + "/statefull_nodes")
 	if err != nil {
 		return []ExtraNode{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []ExtraNode{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []ExtraNode{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []ExtraNode{}, unexpectedHTTPError(code, body)
+		return []ExtraNode{}, res.unexpectedError()
 	}
 
 	var extraNodesByID []ExtraNode
 
-	if err := json.Unmarshal(body, &extraNodesByID); err != nil {
+	if err := json.Unmarshal(res.body, &extraNodesByID); err != nil {
 		return []ExtraNode{}, err
 	}
 
@@ -51,22 +51,22 @@ func (c *Client) AddExtraNode(projectUUID string, name string, nodeType string,
 		"node_type": nodeType,
 		"zone":      zone,
 	}
-	code, body, err := c.post("/projects/"+projectUUID+"/statefull_nodes", &payload)
+	res, err := c.post("/projects/"+projectUUID+"/statefull_nodes", &payload)
 	if err != nil {
 		return newExtraNode, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 	case http.StatusNotFound:
-		return newExtraNode, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return newExtraNode, res.errorf("Project '%s' does not exist", projectUUID)
 	case http.StatusConflict:
-		return newExtraNode, fmt.Errorf("extra-node already exist on project '%s': %s", projectUUID, name)
+		return newExtraNode, res.errorf("extra-node already exist on project '%s': %s", projectUUID, name)
 	default:
-		return newExtraNode, unexpectedHTTPError(code, body)
+		return newExtraNode, res.unexpectedError()
 	}
 
-	if err := json.Unmarshal(body, &newExtraNode); err != nil {
+	if err := json.Unmarshal(res.body, &newExtraNode); err != nil {
 		return newExtraNode, err
 	}
 
@@ -86,43 +86,49 @@ func (c *Client) GetExtraNodeInfo(projectUUID string, name string) (ExtraNode, e
 		}
 	}
 
-	return ExtraNode{}, fmt.Errorf("extra-node '%s' not found for project '%s'", name, projectUUID)
+	return ExtraNode{}, notFoundError("/projects/"+projectUUID+"/statefull_nodes", "extra-node '%s' not found for project '%s'", name, projectUUID)
 }
 
 // WaitExtraNode wait a new extra-node
 func (c *Client) WaitExtraNode(projectUUID string, name string, timeToWait int64) (ExtraNode, error) {
-	extraNode, err := c.GetExtraNodeInfo(projectUUID, name)
-	if err != nil {
-		return extraNode, err
-	}
+	opts := WaitOptions{Interval: time.Duration(timeToWait) * time.Second}
+	return c.WaitExtraNodeWithOptions(projectUUID, name, opts)
+}
 
-	for extraNode.Status != "provisionned" && err == nil {
-		time.Sleep(time.Duration(timeToWait) * time.Second)
-		extraNode, err = c.GetExtraNodeInfo(projectUUID, name)
-		logger.Debug.Println("extraNode status update: ", extraNode.Name)
-	}
+// WaitExtraNodeWithOptions wait until the extra-node is provisionned
+func (c *Client) WaitExtraNodeWithOptions(projectUUID string, name string, opts WaitOptions) (ExtraNode, error) {
+	var extraNode ExtraNode
+	_, err := c.Wait(projectUUID, opts, func(client *Client) (string, bool, error) {
+		var err error
+		extraNode, err = client.GetExtraNodeInfo(projectUUID, name)
+		if err != nil {
+			return "", false, err
+		}
+		logger.Debug.Println("extraNode status update: ", extraNode.Name, " Status: ", extraNode.Status)
+		return extraNode.Status, extraNode.Status == "provisionned", nil
+	})
 
 	return extraNode, err
 }
 
 // DeleteExtraNode delete a existing extra-node
 func (c *Client) DeleteExtraNode(projectUUID string, name string) error {
-	code, body, err := c.delete("/projects/" + projectUUID + "/statefull_nodes/" + name)
+	res, err := c.delete("/projects/" + projectUUID + "/statefull_nodes/" + name)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		if fmt.Sprintf("%s", body) == `{"error":"Couldn't find ExtraNode with [WHERE \"statefull_nodes\".\"cluster_id\" = $1 AND \"statefull_nodes\".\"name\" = $2]"}` {
-			return fmt.Errorf("extra-node '%s' does not exist", name)
+		if fmt.Sprintf("%s", res.body) == `{"error":"Couldn't find ExtraNode with [WHERE \"statefull_nodes\".\"cluster_id\" = $1 AND \"statefull_nodes\".\"name\" = $2]"}` {
+			return res.errorf("extra-node '%s' does not exist", name)
 		}
-		return fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return res.errorf("Project '%s' does not exist", projectUUID)
 	case http.StatusBadRequest:
-		return fmt.Errorf("Deploy probably in progress")
+		return res.errorf("Deploy probably in progress")
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -139,22 +145,22 @@ func (c *Client) BindVolumeOnExtraNode(projectUUID string, name string, volumeNa
 		"volumes_to_bind":   volumeToBind,
 		"volumes_to_unbind": volumeToUnbind,
 	}
-	code, body, err := c.put("/projects/"+projectUUID+"/statefull_nodes/"+name, &payload)
+	res, err := c.put("/projects/"+projectUUID+"/statefull_nodes/"+name, &payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		if fmt.Sprintf("%s", body) == `{"error":"Couldn't find Volume with [WHERE \"volumes\".\"cluster_id\" = $1 AND \"volumes\".\"name\" = $2]"}` {
-			return fmt.Errorf("Volume '%s' does not exist", volumeName)
+		if fmt.Sprintf("%s", res.body) == `{"error":"Couldn't find Volume with [WHERE \"volumes\".\"cluster_id\" = $1 AND \"volumes\".\"name\" = $2]"}` {
+			return res.errorf("Volume '%s' does not exist", volumeName)
 		}
-		return fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return res.errorf("Project '%s' does not exist", projectUUID)
 	case http.StatusBadRequest:
-		return fmt.Errorf("Volume %s already bound with %s", volumeName, name)
+		return res.errorf("Volume %s already bound with %s", volumeName, name)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
@@ -2,7 +2,6 @@ package squarescale
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 )
@@ -20,22 +19,22 @@ type InfrastructureAction struct {
 
 // GetInfrastructureActions gets all the infrastructure Actions attached to a Project
 func (c *Client) GetInfrastructureActions(projectUUID string) ([]InfrastructureAction, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/infrastructure_actions")
+	res, err := c.get("/projects/" + projectUUID + "/infrastructure_actions")
 	if err != nil {
 		return []InfrastructureAction{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []InfrastructureAction{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []InfrastructureAction{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []InfrastructureAction{}, unexpectedHTTPError(code, body)
+		return []InfrastructureAction{}, res.unexpectedError()
 	}
 
 	var infrastructureActionsByMostRecent []InfrastructureAction
 
-	if err := json.Unmarshal(body, &infrastructureActionsByMostRecent); err != nil {
+	if err := json.Unmarshal(res.body, &infrastructureActionsByMostRecent); err != nil {
 		return []InfrastructureAction{}, err
 	}
 
@@ -19,23 +19,23 @@ type LoadBalancer struct {
 
 // LoadBalancerGet get details for load balancer
 func (c *Client) LoadBalancerGet(projectUUID string) ([]LoadBalancer, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/load_balancers")
+	res, err := c.get("/projects/" + projectUUID + "/load_balancers")
 
 	if err != nil {
 		return []LoadBalancer{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []LoadBalancer{}, fmt.Errorf("Project '%s' not found", projectUUID)
+		return []LoadBalancer{}, res.errorf("Project '%s' not found", projectUUID)
 	default:
-		return []LoadBalancer{}, unexpectedHTTPError(code, body)
+		return []LoadBalancer{}, res.unexpectedError()
 	}
 
 	var loadBalancers []LoadBalancer
 
-	err = json.Unmarshal(body, &loadBalancers)
+	err = json.Unmarshal(res.body, &loadBalancers)
 	if err != nil {
 		return []LoadBalancer{}, err
 	}
@@ -54,18 +54,18 @@ func (c *Client) LoadBalancerEnable(projectUUID string, loadBalancerID int64, ce
 	}
 
 	URL := fmt.Sprintf("/projects/%s/load_balancers/%d", projectUUID, loadBalancerID)
-	code, body, err := c.put(URL, payload)
+	res, err := c.put(URL, payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' not found", projectUUID)
+		return res.errorf("Project '%s' not found", projectUUID)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
 
@@ -77,17 +77,17 @@ func (c *Client) LoadBalancerDisable(projectUUID string, loadBalancerID int64) e
 	}
 
 	URL := fmt.Sprintf("/projects/%s/load_balancers/%d", projectUUID, loadBalancerID)
-	code, body, err := c.put(URL, payload)
+	res, err := c.put(URL, payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' not found", projectUUID)
+		return res.errorf("Project '%s' not found", projectUUID)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
@@ -93,19 +93,18 @@ func (p *NetworkPolicy) String() string {
 }
 
 func (c *Client) GetNetworkPolicy(projectUUID, version string) (*NetworkPolicy, error) {
-	var code int
-	var body []byte
+	var res *response
 	var err error
 	var current *NetworkPolicy
 
 	if version != "" {
-		code, body, err = c.get("/projects/" + projectUUID + "/network_policies/" + version)
+		res, err = c.get("/projects/" + projectUUID + "/network_policies/" + version)
 		current, err = c.GetNetworkPolicy(projectUUID, "")
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		code, body, err = c.get("/projects/" + projectUUID + "/network_policy")
+		res, err = c.get("/projects/" + projectUUID + "/network_policy")
 	}
 
 	if err != nil {
@@ -113,19 +112,19 @@ func (c *Client) GetNetworkPolicy(projectUUID, version string) (*NetworkPolicy,
 	}
 
 	var policy NetworkPolicy
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
 		return &policy, nil
 	default:
-		return nil, unexpectedHTTPError(code, body)
+		return nil, res.unexpectedError()
 	}
 
-	if string(body) == "" || string(body) == "[]" {
+	if string(res.body) == "" || string(res.body) == "[]" {
 		return &policy, nil
 	}
 
-	err = json.Unmarshal(body, &policy)
+	err = json.Unmarshal(res.body, &policy)
 	if err != nil {
 		return nil, err
 	}
@@ -149,56 +148,56 @@ func (c *Client) GetNetworkPolicy(projectUUID, version string) (*NetworkPolicy,
 }
 
 func (c *Client) DeployNetworkPolicy(projectUUID, version string) error {
-	code, body, err := c.put("/projects/"+projectUUID+"/network_policies/"+version+"/deploy", nil)
+	res, err := c.put("/projects/"+projectUUID+"/network_policies/"+version+"/deploy", nil)
 	if err != nil {
 		return err
 	}
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return errors.New("version not found")
+		return res.errorf("version not found")
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 	return nil
 }
 
 func (c *Client) DeleteNetworkPolicy(projectUUID, version string) error {
-	code, body, err := c.delete("/projects/" + projectUUID + "/network_policies/" + version)
+	res, err := c.delete("/projects/" + projectUUID + "/network_policies/" + version)
 	if err != nil {
 		return err
 	}
-	switch code {
+	switch res.code {
 	case http.StatusNoContent:
 	case http.StatusNotFound:
-		return errors.New("version not found")
+		return res.errorf("version not found")
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 	return nil
 }
 
 func (c *Client) ListNetworkPolicies(projectUUID string) (*NetworkPolicy, []NetworkPolicyVersions, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/network_policies")
+	res, err := c.get("/projects/" + projectUUID + "/network_policies")
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var versions []NetworkPolicyVersions
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
 		return nil, nil, nil
 	default:
-		return nil, nil, unexpectedHTTPError(code, body)
+		return nil, nil, res.unexpectedError()
 	}
 
-	if string(body) == "" || string(body) == "[]" {
+	if string(res.body) == "" || string(res.body) == "[]" {
 		return nil, nil, nil
 	}
 
-	err = json.Unmarshal(body, &versions)
+	err = json.Unmarshal(res.body, &versions)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -218,24 +217,24 @@ func (c *Client) AddNetworkPolicy(projectUUID, name, rules string) (string, erro
 		"project_uuid": projectUUID,
 	}
 
-	code, body, err := c.post("/projects/"+projectUUID+"/network_policies", &payload)
+	res, err := c.post("/projects/"+projectUUID+"/network_policies", &payload)
 	if err != nil {
 		return "", err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	default:
-		return "", unexpectedHTTPError(code, body)
+		return "", res.unexpectedError()
 	}
 
-	if string(body) == "" || string(body) == "[]" {
+	if string(res.body) == "" || string(res.body) == "[]" {
 		return "unknown", nil
 	}
 
 	var policyVersion map[string]string
 
-	err = json.Unmarshal(body, &policyVersion)
+	err = json.Unmarshal(res.body, &policyVersion)
 	if err != nil {
 		return "", err
 	}
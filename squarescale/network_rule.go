@@ -18,22 +18,22 @@ type NetworkRule struct {
 
 func (c *Client) ListNetworkRules(projectUUID, serviceName string) ([]NetworkRule, error) {
 
-	code, body, err := c.get(fmt.Sprintf("/projects/%s/services/%s/service_network_rules", projectUUID, serviceName))
+	res, err := c.get(fmt.Sprintf("/projects/%s/services/%s/service_network_rules", projectUUID, serviceName))
 	if err != nil {
 		return nil, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []NetworkRule{}, fmt.Errorf("Project '%s' and/or service container '%s' does not exist", projectUUID, serviceName)
+		return []NetworkRule{}, res.errorf("Project '%s' and/or service container '%s' does not exist", projectUUID, serviceName)
 	default:
-		return []NetworkRule{}, unexpectedHTTPError(code, body)
+		return []NetworkRule{}, res.unexpectedError()
 	}
 
 	var netRules []NetworkRule
 
-	if err := json.Unmarshal(body, &netRules); err != nil {
+	if err := json.Unmarshal(res.body, &netRules); err != nil {
 		return []NetworkRule{}, err
 	}
 
@@ -42,13 +42,13 @@ func (c *Client) ListNetworkRules(projectUUID, serviceName string) ([]NetworkRul
 
 func (c *Client) CreateNetworkRule(projectUUID, serviceName string, newRule NetworkRule) error {
 
-	code, body, err := c.post(fmt.Sprintf("/projects/%s/services/%s/service_network_rules", projectUUID, serviceName), newRule)
+	res, err := c.post(fmt.Sprintf("/projects/%s/services/%s/service_network_rules", projectUUID, serviceName), newRule)
 	if err != nil {
 		return err
 	}
 
-	if code != http.StatusCreated {
-		return unexpectedHTTPError(code, body)
+	if res.code != http.StatusCreated {
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -56,17 +56,17 @@ func (c *Client) CreateNetworkRule(projectUUID, serviceName string, newRule Netw
 
 func (c *Client) DeleteNetworkRule(projectUUID, serviceName string, ruleToDelete string) error {
 
-	code, body, err := c.delete(fmt.Sprintf("/projects/%s/services/%s/service_network_rules/%s", projectUUID, serviceName, ruleToDelete))
+	res, err := c.delete(fmt.Sprintf("/projects/%s/services/%s/service_network_rules/%s", projectUUID, serviceName, ruleToDelete))
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' and/or service container '%s' or network rule '%s' does not exist", projectUUID, serviceName, ruleToDelete)
+		return res.errorf("Project '%s' and/or service container '%s' or network rule '%s' does not exist", projectUUID, serviceName, ruleToDelete)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -30,42 +30,42 @@ func (c *Client) AddOrganization(name, email string) error {
 	}
 
 	url := fmt.Sprintf("/organizations")
-	code, body, err := c.post(url, &payload)
+	res, err := c.post(url, &payload)
 
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 		return nil
 	case http.StatusConflict:
-		return fmt.Errorf("Organization already exist: %s", name)
+		return res.errorf("Organization already exist: %s", name)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
 
 // GetOrganizationInfo gets the organization based on its name.
 func (c *Client) GetOrganizationInfo(name string) (Organization, error) {
 	url := fmt.Sprintf("/organizations/%s", name)
-	code, body, err := c.get(url)
+	res, err := c.get(url)
 
 	if err != nil {
 		return Organization{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return Organization{}, fmt.Errorf("Organization '%s' not found", name)
+		return Organization{}, res.errorf("Organization '%s' not found", name)
 	default:
-		return Organization{}, unexpectedHTTPError(code, body)
+		return Organization{}, res.unexpectedError()
 	}
 
 	var organizationByName Organization
 
-	if err := json.Unmarshal(body, &organizationByName); err != nil {
+	if err := json.Unmarshal(res.body, &organizationByName); err != nil {
 		return Organization{}, err
 	}
 
@@ -74,18 +74,18 @@ func (c *Client) GetOrganizationInfo(name string) (Organization, error) {
 
 // ListOrganizations asks the SquareScale service for available organizations.
 func (c *Client) ListOrganizations() ([]Organization, error) {
-	code, body, err := c.get("/organizations")
+	res, err := c.get("/organizations")
 
 	if err != nil {
 		return nil, err
 	}
 
-	if code != http.StatusOK {
-		return nil, unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return nil, res.unexpectedError()
 	}
 
 	var organizationsJSON []Organization
-	err = json.Unmarshal(body, &organizationsJSON)
+	err = json.Unmarshal(res.body, &organizationsJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -96,18 +96,18 @@ func (c *Client) ListOrganizations() ([]Organization, error) {
 // DeleteOrganization delete organization based on its name.
 func (c *Client) DeleteOrganization(name string) error {
 	url := fmt.Sprintf("/organizations/%s", name)
-	code, body, err := c.delete(url)
+	res, err := c.delete(url)
 
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("No organization found for name: %s", name)
+		return res.errorf("No organization found for name: %s", name)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
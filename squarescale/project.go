@@ -17,8 +17,9 @@ type Project struct {
 	UUID                 string    `json:"uuid"`
 	Provider             string    `json:"provider"`
 	Region               string    `json:"region"`
-	Organization         string    `string:"organization"`
+	Organization         string    `json:"organization"`
 	InfraStatus          string    `json:"infra_status"`
+	InfraType            string    `json:"infra_type"`
 	ClusterSize          int       `json:"cluster_size"`
 	NomadNodesReady      int       `json:"nomad_nodes_ready"`
 	MonitoringEnabled    bool      `json:"monitoring_enabled"`
@@ -275,16 +276,16 @@ func (c *Client) CreateProject(payload *JSONObject) (newProject Project, err err
 		return newProject, errors.New("Credential is mandatory")
 	}
 
-	code, body, err := c.post("/projects", payload)
+	res, err := c.post("/projects", payload)
 	if err != nil {
 		return newProject, err
 	}
 
-	if code != http.StatusCreated {
-		return newProject, unexpectedHTTPError(code, body)
+	if res.code != http.StatusCreated {
+		return newProject, res.unexpectedError()
 	}
 
-	err = json.Unmarshal(body, &newProject)
+	err = json.Unmarshal(res.body, &newProject)
 	if err != nil {
 		return newProject, err
 	}
@@ -299,13 +300,13 @@ func (c *Client) CreateProject(payload *JSONObject) (newProject Project, err err
 // ProvisionProject asks the SquareScale platform to provision the project
 func (c *Client) ProvisionProject(projectUUID string) (err error) {
 
-	code, body, err := c.post(fmt.Sprintf("/projects/%s/provision", projectUUID), nil)
+	res, err := c.post(fmt.Sprintf("/projects/%s/provision", projectUUID), nil)
 	if err != nil {
 		return err
 	}
 
-	if code != http.StatusNoContent {
-		return unexpectedHTTPError(code, body)
+	if res.code != http.StatusNoContent {
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -314,13 +315,13 @@ func (c *Client) ProvisionProject(projectUUID string) (err error) {
 // UNProvisionProject asks the SquareScale platform to provision the project
 func (c *Client) UNProvisionProject(projectUUID string) (err error) {
 
-	code, body, err := c.post(fmt.Sprintf("/projects/%s/unprovision", projectUUID), nil)
+	res, err := c.post(fmt.Sprintf("/projects/%s/unprovision", projectUUID), nil)
 	if err != nil {
 		return err
 	}
 
-	if code != http.StatusNoContent {
-		return unexpectedHTTPError(code, body)
+	if res.code != http.StatusNoContent {
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -328,17 +329,17 @@ func (c *Client) UNProvisionProject(projectUUID string) (err error) {
 
 // ListProjects asks the SquareScale service for available projects.
 func (c *Client) ListProjects() ([]Project, error) {
-	code, body, err := c.get("/projects")
+	res, err := c.get("/projects")
 	if err != nil {
 		return nil, err
 	}
 
-	if code != http.StatusOK {
-		return nil, unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return nil, res.unexpectedError()
 	}
 
 	var projectsJSON []Project
-	err = json.Unmarshal(body, &projectsJSON)
+	err = json.Unmarshal(res.body, &projectsJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -397,26 +398,26 @@ func (c *Client) ProjectByName(projectName string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("Project '%s' not found", projectName)
+	return "", notFoundError("/projects", "Project '%s' not found", projectName)
 }
 
 // GetProjectDetails return the detailed informations of the project
 func (c *Client) GetProjectDetails(project string) (*ProjectWithAllDetails, error) {
-	code, body, err := c.get("/project_info/" + project)
+	res, err := c.get("/project_info/" + project)
 	if err != nil {
 		return nil, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return nil, fmt.Errorf("Project '%s' not found", project)
+		return nil, res.errorf("Project '%s' not found", project)
 	default:
-		return nil, unexpectedHTTPError(code, body)
+		return nil, res.unexpectedError()
 	}
 
 	var details ProjectWithAllDetails
-	err = json.Unmarshal(body, &details)
+	err = json.Unmarshal(res.body, &details)
 	if err != nil {
 		fmt.Printf("Error decoding project details %+v\n", err)
 		return nil, err
@@ -427,21 +428,21 @@ func (c *Client) GetProjectDetails(project string) (*ProjectWithAllDetails, erro
 
 // GetProject return the basic infos of the project
 func (c *Client) GetProject(project string) (*Project, error) {
-	code, body, err := c.get("/projects/" + project)
+	res, err := c.get("/projects/" + project)
 	if err != nil {
 		return nil, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return nil, fmt.Errorf("Project '%s' not found", project)
+		return nil, res.errorf("Project '%s' not found", project)
 	default:
-		return nil, unexpectedHTTPError(code, body)
+		return nil, res.unexpectedError()
 	}
 
 	var basicInfos Project
-	err = json.Unmarshal(body, &basicInfos)
+	err = json.Unmarshal(res.body, &basicInfos)
 	if err != nil {
 		return nil, err
 	}
@@ -451,63 +452,96 @@ func (c *Client) GetProject(project string) (*Project, error) {
 
 // WaitProject wait project provisioning
 func (c *Client) WaitProject(projectUUID string, timeToWait int64) (string, error) {
-	project, err := c.GetProject(projectUUID)
-	if err != nil {
-		return "", err
-	}
+	opts := WaitOptions{Interval: time.Duration(timeToWait) * time.Second}
+	return c.WaitProjectWithOptions(projectUUID, opts)
+}
 
+// WaitProjectWithOptions wait project provisioning until its infrastructure is
+// either ok or in error
+func (c *Client) WaitProjectWithOptions(projectUUID string, opts WaitOptions) (string, error) {
 	logger.Info.Println("wait for project : ", projectUUID)
+	return c.WaitProjectStatus(projectUUID, []string{"ok"}, opts)
+}
 
-	for err == nil && project == nil && !(project.InfraStatus == "ok" || project.InfraStatus == "error") {
-		time.Sleep(time.Duration(timeToWait) * time.Second)
-		project, err = c.GetProject(projectUUID)
+// WaitProjectStatus wait until the project infrastructure reaches one of the
+// target statuses. An infrastructure in error ends the wait with the log of
+// the latest infrastructure action.
+func (c *Client) WaitProjectStatus(projectUUID string, targetStatuses []string, opts WaitOptions) (string, error) {
+	return c.Wait(projectUUID, opts, func(client *Client) (string, bool, error) {
+		project, err := client.GetProject(projectUUID)
 		if err != nil {
-			return "", err
+			return "", false, err
+		}
+		logger.Debug.Println("project status update: ", projectUUID, " Status: ", project.InfraStatus)
+
+		if project.InfraStatus == "error" {
+			actions, err := client.GetInfrastructureActions(project.UUID)
+			if err != nil {
+				return project.InfraStatus, false, err
+			}
+			if len(actions) == 0 {
+				return project.InfraStatus, false, errors.New("Unable to retrieve latest project deployment log")
+			}
+			return project.InfraStatus, false, errors.New(actions[0].Log)
 		}
-		logger.Debug.Println("project status update: ", projectUUID)
-	}
-
-	if project == nil {
-		return "", fmt.Errorf("Project %s not found", projectUUID)
-	}
 
-	if project.InfraStatus == "error" {
-		actions, err := c.GetInfrastructureActions(project.UUID)
-		if err != nil {
-			return "", err
+		for _, status := range targetStatuses {
+			if project.InfraStatus == status {
+				return project.InfraStatus, true, nil
+			}
 		}
-		if len(actions) == 0 {
-			return project.InfraStatus, errors.New("Unable to retrieve latest project deployment log")
-		} else {
-			return project.InfraStatus, errors.New(actions[0].Log)
+		return project.InfraStatus, false, nil
+	})
+}
+
+// WaitProjectDeleted wait until the project does not exist anymore
+func (c *Client) WaitProjectDeleted(projectUUID string, opts WaitOptions) error {
+	_, err := c.Wait(projectUUID, opts, func(client *Client) (string, bool, error) {
+		res, err := client.get("/projects/" + projectUUID)
+		if err != nil && res.code != http.StatusNotFound {
+			return "", false, err
 		}
-	}
 
-	return project.InfraStatus, err
+		switch res.code {
+		case http.StatusOK:
+			var project Project
+			err = json.Unmarshal(res.body, &project)
+			if err != nil {
+				return "", false, err
+			}
+			logger.Debug.Println("project status update: ", projectUUID, " Status: ", project.InfraStatus)
+			return project.InfraStatus, false, nil
+		case http.StatusNotFound:
+			return "deleted", true, nil
+		default:
+			return "", false, res.unexpectedError()
+		}
+	})
+	return err
 }
 
 // ProjectUnprovision unprovisions a project
 func (c *Client) ProjectUnprovision(project string) error {
-	code, body, err := c.post("/projects/"+project+"/unprovision", nil)
+	res, err := c.post("/projects/"+project+"/unprovision", nil)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusAccepted:
 	case http.StatusNoContent:
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' not found", project)
+		return res.errorf("Project '%s' not found", project)
 	case http.StatusUnprocessableEntity:
 		var errJSON UnprovisionError
-		err = json.Unmarshal(body, &errJSON)
+		err = json.Unmarshal(res.body, &errJSON)
 		if err != nil {
 			return err
 		}
-		return fmt.Errorf("Operation failed: %s", errJSON.Errors.Unprovision)
+		return res.errorf("Operation failed: %s", errJSON.Errors.Unprovision)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -515,125 +549,103 @@ func (c *Client) ProjectUnprovision(project string) error {
 
 // ProjectDelete deletes an unprovisionned project
 func (c *Client) ProjectDelete(project string) error {
-	code, body, err := c.delete("/projects/" + project)
+	res, err := c.delete("/projects/" + project)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNoContent:
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' not found", project)
+		return res.errorf("Project '%s' not found", project)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 
 	return nil
 }
 
+// LogEntry describes a project log record as returned by the SquareScale API
+type LogEntry struct {
+	Timestamp     string `json:"timestamp"`
+	ProjectName   string `json:"project_name"`
+	ContainerName string `json:"container_name"`
+	Error         bool   `json:"error"`
+	Type          string `json:"type"`
+	Message       string `json:"message"`
+	Level         int    `json:"level"`
+}
+
+// Time parses the timestamp of the log entry
+func (l *LogEntry) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, l.Timestamp)
+}
+
+// LevelName returns the name of the log entry level (INFO, WARN or ERROR)
+func (l *LogEntry) LevelName() string {
+	if l.Level >= 5 {
+		return "INFO"
+	} else if l.Level >= 4 {
+		return "WARN"
+	}
+	return "ERROR"
+}
+
 // ProjectLogs gets the logs for a project container.
-func (c *Client) ProjectLogs(project string, container string, after string) ([]string, string, error) {
+// It returns the log entries following the after cursor and the cursor
+// to use to get the next ones.
+func (c *Client) ProjectLogs(project string, container string, after string) ([]LogEntry, string, error) {
 	query := ""
 	if after != "" {
 		query = "?after=" + url.QueryEscape(after)
 	}
 
-	code, body, err := c.get("/projects/" + project + "/logs/" + url.QueryEscape(container) + query)
+	res, err := c.get("/projects/" + project + "/logs/" + url.QueryEscape(container) + query)
 	if err != nil {
-		return []string{}, "", err
+		return []LogEntry{}, "", err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusBadRequest:
-		return []string{}, "", fmt.Errorf("Project '%s' not found", project)
+		// the API answers 400 to an unknown project
+		return []LogEntry{}, "", notFoundError(res.path, "Project '%s' not found", project)
 	case http.StatusNotFound:
-		return []string{}, "", fmt.Errorf("Container '%s' is not found for project '%s'", container, project)
+		return []LogEntry{}, "", res.errorf("Container '%s' is not found for project '%s'", container, project)
 	default:
-		return []string{}, "", unexpectedHTTPError(code, body)
-	}
-
-	var response []struct {
-		Timestamp     string `json:"timestamp"`
-		ProjectName   string `json:"project_name"`
-		ContainerName string `json:"container_name"`
-		Error         bool   `json:"error"`
-		Type          string `json:"type"`
-		Message       string `json:"message"`
-		Level         int    `json:"level"`
+		return []LogEntry{}, "", res.unexpectedError()
 	}
-	err = json.Unmarshal(body, &response)
 
+	var entries []LogEntry
+	err = json.Unmarshal(res.body, &entries)
 	if err != nil {
-		return []string{}, "", err
-	}
-
-	var messages []string
-	for _, log := range response {
-		var linePattern string
-		var lt string
-		var containerWithBrackets string
-		var level string
-		if log.Type == "docker" {
-			lt = "docker"
-		} else if log.Type == "nomad" {
-			lt = "nomad "
-		} else if log.Type == "event" {
-			lt = "sqsc  "
-		} else {
-			lt = ""
-		}
-		if log.ContainerName == "" {
-			containerWithBrackets = ""
-		} else {
-			containerWithBrackets = "[" + log.ContainerName + "] "
-		}
-		if log.Level >= 5 {
-			level = "INFO "
-		} else if log.Level >= 4 {
-			level = "WARN "
-		} else {
-			level = "ERROR"
-		}
-		linePattern = "%s %s %s-- [%s] %s"
-		if log.Error {
-			linePattern = "\033[0;33m" + linePattern + "\033[0m"
-		}
-		t, err := time.Parse(time.RFC3339Nano, log.Timestamp)
-		if err == nil {
-			formatedTime := t.Format("2006-01-02 15:04:05.999")
-			padTime := fmt.Sprintf("%-23s", formatedTime)
-			messages = append(messages, fmt.Sprintf(linePattern, padTime, lt, containerWithBrackets, level, log.Message))
-		} else {
-			messages = append(messages, fmt.Sprintf(linePattern, log.Timestamp, lt, containerWithBrackets, level, log.Message))
-		}
+		return []LogEntry{}, "", err
 	}
+
 	var lastTimestamp string
-	if len(response) == 0 {
-		lastTimestamp = ""
-	} else {
-		lastTimestamp = response[len(response)-1].Timestamp
+	if len(entries) > 0 {
+		lastTimestamp = entries[len(entries)-1].Timestamp
 	}
 
-	return messages, lastTimestamp, nil
+	return entries, lastTimestamp, nil
 }
 
 // ConfigProjectSettings configure project settings
 func (c *Client) ConfigProjectSettings(projectUUID string, project Project) error {
 	payload := &JSONObject{"hybrid_cluster_enabled": project.HybridClusterEnabled}
 
-	code, body, err := c.put(fmt.Sprintf("/projects/%s", projectUUID), payload)
+	res, err := c.put(fmt.Sprintf("/projects/%s", projectUUID), payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' not found", projectUUID)
+		return res.errorf("Project '%s' not found", projectUUID)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
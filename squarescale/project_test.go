@@ -53,6 +53,10 @@ func TestProject(t *testing.T) {
 	t.Run("Project not found on ProjectDelete", UnknownProjectOnProjectDelete)
 	t.Run("Badly http error code case on ProjectDelete", badHttpErrrorCoreCaseOnProjectDelete)
 
+	// ProjectLogs
+	t.Run("Nominal case on ProjectLogs", nominalCaseProjectLogs)
+	t.Run("Container not found on ProjectLogs", UnknownContainerOnProjectLogs)
+
 	// ConfigProjectSettings
 	t.Run("Nominal case on ConfigProjectSettings", nominalCaseConfigProjectSettings)
 	t.Run("Project not found on ConfigProjectSettings", UnknownProjectOnConfigProjectSettings)
@@ -861,12 +865,20 @@ func nominalCaseWaitProject(t *testing.T) {
 	cli := squarescale.NewClient(server.URL, token)
 
 	// when
-	_, err := cli.WaitProject(projectUUID, 0)
+	status, err := cli.WaitProject(projectUUID, 0)
 
 	// then
 	if err != nil {
 		t.Fatalf("Expect no error, got `%s`", err)
 	}
+
+	if status != "ok" {
+		t.Fatalf("Expect status `ok`, got `%s`", status)
+	}
+
+	if httptestCount != 2 {
+		t.Fatalf("Expect 2 calls, got `%d`", httptestCount)
+	}
 }
 
 func UnknownProjectOnGetProject(t *testing.T) {
@@ -1243,7 +1255,125 @@ func CantUnmarshalOnProjectMethods(t *testing.T) {
 // TODO: see what the following comments are really meant for
 // ProjectUnprovision(project string) error {
 // ProjectDelete(project string) error {
-// ProjectLogs(project string, container string, after string) ([]string, string, error) {
+
+func nominalCaseProjectLogs(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "8cfe8f68-cad5-4157-b8a6-d9efa12caf0e"
+	after := "2023-08-17T10:00:00.000Z"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkPath(t, "/projects/"+projectUUID+"/logs/web", r.URL.Path)
+		checkAuthorization(t, r.Header.Get("Authorization"), token)
+		if r.URL.Query().Get("after") != after {
+			t.Fatalf("Expect after query `%s`, got `%s`", after, r.URL.Query().Get("after"))
+		}
+
+		resBody := `
+		[
+			{
+				"timestamp": "2023-08-17T10:00:01.123Z",
+				"project_name": "my-project",
+				"container_name": "web",
+				"error": false,
+				"type": "docker",
+				"message": "listening on :8080",
+				"level": 6
+			},
+			{
+				"timestamp": "2023-08-17T10:00:02.456Z",
+				"project_name": "my-project",
+				"container_name": "web",
+				"error": true,
+				"type": "nomad",
+				"message": "allocation failed",
+				"level": 3
+			}
+		]
+		`
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(resBody))
+	}))
+
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, token)
+
+	// when
+	entries, cursor, err := cli.ProjectLogs(projectUUID, "web", after)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expect 2 log entries, got `%d`", len(entries))
+	}
+
+	expectedString := "2023-08-17T10:00:02.456Z"
+	if cursor != expectedString {
+		t.Fatalf("Expect cursor `%s`, got `%s`", expectedString, cursor)
+	}
+
+	expectedString = "listening on :8080"
+	if entries[0].Message != expectedString {
+		t.Fatalf("Expect entries[0].Message `%s`, got `%s`", expectedString, entries[0].Message)
+	}
+
+	expectedString = "INFO"
+	if entries[0].LevelName() != expectedString {
+		t.Fatalf("Expect entries[0].LevelName() `%s`, got `%s`", expectedString, entries[0].LevelName())
+	}
+
+	expectedString = "ERROR"
+	if entries[1].LevelName() != expectedString {
+		t.Fatalf("Expect entries[1].LevelName() `%s`, got `%s`", expectedString, entries[1].LevelName())
+	}
+
+	if !entries[1].Error {
+		t.Fatalf("Expect entries[1].Error to be true")
+	}
+
+	entryTime, err := entries[1].Time()
+	if err != nil {
+		t.Fatalf("Expect no error parsing timestamp, got `%s`", err)
+	}
+	if entryTime.Second() != 2 {
+		t.Fatalf("Expect entries[1].Time() second `2`, got `%d`", entryTime.Second())
+	}
+}
+
+func UnknownContainerOnProjectLogs(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "8cfe8f68-cad5-4157-b8a6-d9efa12caf0e"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkPath(t, "/projects/"+projectUUID+"/logs/unknown", r.URL.Path)
+		checkAuthorization(t, r.Header.Get("Authorization"), token)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(404)
+		w.Write([]byte(`{"error":"Not found"}`))
+	}))
+
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, token)
+
+	// when
+	_, _, err := cli.ProjectLogs(projectUUID, "unknown", "")
+
+	// then
+	expectedError := "Container 'unknown' is not found for project '8cfe8f68-cad5-4157-b8a6-d9efa12caf0e'"
+	if err == nil {
+		t.Fatalf("Error is not raised with `%s`", expectedError)
+	}
+
+	if fmt.Sprintf("%s", err) != expectedError {
+		t.Fatalf("Expected error message:\n`%s`\nGot:\n`%s`", expectedError, err)
+	}
+}
 
 func nominalCaseConfigProjectSettings(t *testing.T) {
 	// given
@@ -22,25 +22,25 @@ type RedisDbConfig struct {
 
 // GetRedis gets all the redis attached to a Project
 func (c *Client) GetRedis(projectUUID string) ([]RedisDbConfig, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/redis_databases")
+	res, err := c.get("/projects/" + projectUUID + "/redis_databases")
 	if err != nil {
 		return []RedisDbConfig{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []RedisDbConfig{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []RedisDbConfig{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []RedisDbConfig{}, unexpectedHTTPError(code, body)
+		return []RedisDbConfig{}, res.unexpectedError()
 	}
 
 	var redesDb Redis
 	var redesDbConfig []RedisDbConfig
 
-	// log.Printf("redis json respond : %s\n", body)
+	// log.Printf("redis json respond : %s\n", res.body)
 
-	if err := json.Unmarshal(body, &redesDb); err != nil {
+	if err := json.Unmarshal(res.body, &redesDb); err != nil {
 		return []RedisDbConfig{}, err
 	}
 	for _, redis := range redesDb.RedisDatabaseConfigs {
@@ -66,27 +66,27 @@ func (c *Client) GetRedisInfo(projectUUID, name string) (RedisDbConfig, error) {
 		}
 	}
 
-	return RedisDbConfig{}, fmt.Errorf("Redis '%s' not found for project '%s'", name, projectUUID)
+	return RedisDbConfig{}, notFoundError("/projects/"+projectUUID+"/redis_databases", "Redis '%s' not found for project '%s'", name, projectUUID)
 }
 
 // DeleteRedis delete redis of a project based on its name.
 func (c *Client) DeleteRedis(projectUUID string, name string) error {
 	url := fmt.Sprintf("/projects/%s/redis_databases/%s", projectUUID, name)
-	code, body, err := c.delete(url)
+	res, err := c.delete(url)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		if fmt.Sprintf("%s", body) == `{"error":"Couldn't find Redis with [WHERE \"redis_databases\".\"cluster_id\" = $1 AND \"redis_databases\".\"name\" = $2]"}` {
-			return fmt.Errorf("{\"error\":\"No redis found for name: %s\"}", name)
+		if fmt.Sprintf("%s", res.body) == `{"error":"Couldn't find Redis with [WHERE \"redis_databases\".\"cluster_id\" = $1 AND \"redis_databases\".\"name\" = $2]"}` {
+			return res.errorf("{\"error\":\"No redis found for name: %s\"}", name)
 		}
-		return fmt.Errorf("%s", body)
+		return res.errorf("%s", res.body)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
 
@@ -97,19 +97,19 @@ func (c *Client) AddRedis(projectUUID string, name string) error {
 	}
 
 	url := fmt.Sprintf("/projects/%s/redis_databases", projectUUID)
-	code, body, err := c.post(url, &payload)
+	res, err := c.post(url, &payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 		return nil
 	case http.StatusConflict:
-		return fmt.Errorf("Redis already exist on project '%s': %s", projectUUID, name)
+		return res.errorf("Redis already exist on project '%s': %s", projectUUID, name)
 	case http.StatusNotFound:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
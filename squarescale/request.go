@@ -2,13 +2,13 @@ package squarescale
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"os"
 	"regexp"
 	"strings"
@@ -16,8 +16,8 @@ import (
 
 	multierr "github.com/hashicorp/go-multierror"
 	mime "github.com/jpillora/go-mime"
-	actioncable "github.com/squarescale/actioncable-go"
 	"github.com/squarescale/logger"
+	"github.com/squarescale/squarescale-cli/redact"
 )
 
 const supportedAPI string = "1"
@@ -27,18 +27,22 @@ type JSONObject map[string]interface{}
 
 // Client is the basic structure to make API calls to SquareScale services
 type Client struct {
-	httpClient        http.Client // http.client is concurrently safe and should be reused across multiple connections
-	cachedCableClient *actioncable.Client
-	endpoint          string
-	token             string
-	user              User
+	httpClient    http.Client // http.client is concurrently safe and should be reused across multiple connections
+	subscriptions *subscriptions
+	ctx           context.Context
+	retry         RetryPolicy
+	endpoint      string
+	token         string
+	user          User
 }
 
 // NewClient creates a new SquareScale client
 func NewClient(endpoint, token string) *Client {
 	c := &Client{
-		endpoint: endpoint,
-		token:    token,
+		endpoint:      endpoint,
+		token:         token,
+		retry:         DefaultRetryPolicy(),
+		subscriptions: &subscriptions{},
 	}
 	return c
 }
@@ -47,64 +51,81 @@ func (c *Client) AddUser(user User) {
 	c.user = user
 }
 
-func (c *Client) cableClient() *actioncable.Client {
-	if c.cachedCableClient == nil {
-		c.cachedCableClient = actioncable.NewClient(strings.Replace(c.endpoint, "http", "ws", 1)+"/cable", c.cableHeaders)
+// User returns the user the token of the client belongs to, as added by
+// AddUser once the token is validated.
+func (c *Client) User() User {
+	return c.user
+}
+
+// WithContext returns a copy of the client whose API calls and waits are
+// bound to ctx: cancelling ctx or reaching its deadline aborts the in-flight
+// HTTP request and any wait loop.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
 	}
-	return c.cachedCableClient
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
 }
 
-func (c *Client) cableHeaders() (*http.Header, error) {
-	h := make(http.Header, 3)
-	h.Set("Authorization", "bearer "+c.token)
-	h.Set("API-Version", supportedAPI)
-	h.Set("Origin", c.endpoint)
-	return &h, nil
+// Context returns the context the client calls are bound to,
+// context.Background() by default
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
 }
 
-func (c *Client) get(path string) (int, []byte, error) {
-	code, response, err := c.request("GET", path, nil)
-	logger.Trace.Println("HTTP Code:", code)
-	logger.Trace.Printf("HTTP Response: %s\n", response)
-	logger.Trace.Println("Err:", err)
-	return code, response, err
+// tracing tells whether the trace logs are written, so that requests and
+// responses are only dumped and redacted when they are
+func tracing() bool {
+	return logger.Trace.Writer() != io.Discard
 }
 
-func (c *Client) post(path string, payload interface{}) (int, []byte, error) {
-	code, response, err := c.request("POST", path, payload)
-	logger.Trace.Println("HTTP Code:", code)
-	logger.Trace.Printf("HTTP Response: %s\n", response)
+func traceResponse(res *response, err error) {
+	if !tracing() {
+		return
+	}
+	logger.Trace.Println("HTTP Code:", res.code)
+	logger.Trace.Printf("HTTP Response: %s\n", redact.JSON(res.body))
 	logger.Trace.Println("Err:", err)
-	return code, response, err
 }
 
-func (c *Client) patch(path string, payload interface{}) (int, []byte, error) {
-	code, response, err := c.request("PATCH", path, payload)
-	logger.Trace.Println("HTTP Code:", code)
-	logger.Trace.Printf("HTTP Response: %s\n", response)
-	logger.Trace.Println("Err:", err)
-	return code, response, err
+func (c *Client) get(path string) (*response, error) {
+	res, err := c.request("GET", path, nil)
+	traceResponse(res, err)
+	return res, err
 }
 
-func (c *Client) delete(path string) (int, []byte, error) {
-	code, response, err := c.request("DELETE", path, nil)
-	logger.Trace.Println("HTTP Code:", code)
-	logger.Trace.Printf("HTTP Response: %s\n", response)
-	logger.Trace.Println("Err:", err)
-	return code, response, err
+func (c *Client) post(path string, payload interface{}) (*response, error) {
+	res, err := c.request("POST", path, payload)
+	traceResponse(res, err)
+	return res, err
 }
 
-func (c *Client) put(path string, payload interface{}) (int, []byte, error) {
-	code, response, err := c.request("PUT", path, payload)
-	logger.Trace.Println("HTTP Code:", code)
-	logger.Trace.Printf("HTTP Response: %s\n", response)
-	logger.Trace.Println("Err:", err)
-	return code, response, err
+func (c *Client) patch(path string, payload interface{}) (*response, error) {
+	res, err := c.request("PATCH", path, payload)
+	traceResponse(res, err)
+	return res, err
+}
+
+func (c *Client) delete(path string) (*response, error) {
+	res, err := c.request("DELETE", path, nil)
+	traceResponse(res, err)
+	return res, err
+}
+
+func (c *Client) put(path string, payload interface{}) (*response, error) {
+	res, err := c.request("PUT", path, payload)
+	traceResponse(res, err)
+	return res, err
 }
 
 func (c *Client) download(path, nodeName string) (int, error) {
 	var bodyReader io.Reader
-	req, err := http.NewRequest("GET", c.endpoint+path, bodyReader)
+	req, err := http.NewRequestWithContext(c.Context(), "GET", c.endpoint+path, bodyReader)
 	if err != nil {
 		return 0, err
 	}
@@ -112,11 +133,13 @@ func (c *Client) download(path, nodeName string) (int, error) {
 	req.Header.Set("Authorization", "bearer "+c.token)
 	req.Header.Set("API-Version", supportedAPI)
 
-	reqDump, err := httputil.DumpRequestOut(req, true)
-	if err != nil {
-		logger.Error.Println("Error dumping HTTP request:", err)
-	} else {
-		logger.Trace.Printf("REQUEST: %s", string(reqDump))
+	if tracing() {
+		reqDump, err := dumpRequest(req, nil)
+		if err != nil {
+			logger.Error.Println("Error dumping HTTP request:", err)
+		} else {
+			logger.Trace.Printf("REQUEST: %s", reqDump)
+		}
 	}
 
 	res, err := c.httpClient.Do(req)
@@ -158,34 +181,69 @@ func (c *Client) download(path, nodeName string) (int, error) {
 
 	// Create the file
 	out, err := os.Create(fmt.Sprintf("%s_%s", nodeName, fName))
-	if err != nil  {
+	if err != nil {
 		return 0, err
 	}
 	defer out.Close()
 
 	// Writer the body to file
 	_, err = io.Copy(out, res.Body)
-	if err != nil  {
+	if err != nil {
 		return 0, err
 	}
 
 	return res.StatusCode, nil
 }
 
-func (c *Client) request(method, path string, payload interface{}) (int, []byte, error) {
-	var bodyReader io.Reader
+// response is an API response along with the request it answers
+type response struct {
+	method string
+	path   string
+	code   int
+	body   []byte
+	header http.Header
+}
+
+func (c *Client) request(method, path string, payload interface{}) (*response, error) {
+	var payloadBytes []byte
 	if payload != nil {
-		payloadBytes, err := json.Marshal(payload)
+		var err error
+		payloadBytes, err = json.Marshal(payload)
 		if err != nil {
-			return 0, []byte{}, err
+			return &response{method: method, path: path, body: []byte{}}, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := c.requestOnce(method, path, payloadBytes)
+
+		delay, retry := c.retryDelay(method, attempt, res.code, res.header, err)
+		if !retry {
+			return res, err
+		}
+
+		logger.Debug.Printf("Retrying %s %s in %s (attempt %d/%d) after: %v", method, path, delay, attempt, c.retry.MaxRetries, retryReason(res.code, err))
+		timer := time.NewTimer(delay)
+		select {
+		case <-c.Context().Done():
+			timer.Stop()
+			return res, err
+		case <-timer.C:
 		}
+	}
+}
+
+func (c *Client) requestOnce(method, path string, payloadBytes []byte) (*response, error) {
+	result := &response{method: method, path: path, body: []byte{}}
 
+	var bodyReader io.Reader
+	if payloadBytes != nil {
 		bodyReader = bytes.NewReader(payloadBytes)
 	}
 
-	req, err := http.NewRequest(method, c.endpoint+path, bodyReader)
+	req, err := http.NewRequestWithContext(c.Context(), method, c.endpoint+path, bodyReader)
 	if err != nil {
-		return 0, []byte{}, err
+		return result, err
 	}
 
 	ct := mime.TypeByExtension(".json")
@@ -195,23 +253,28 @@ func (c *Client) request(method, path string, payload interface{}) (int, []byte,
 	req.Header.Set("Authorization", "bearer "+c.token)
 	req.Header.Set("API-Version", supportedAPI)
 
-	reqDump, err := httputil.DumpRequestOut(req, true)
-	if err != nil {
-		logger.Error.Println("Error dumping HTTP request:", err)
-	} else {
-		logger.Trace.Printf("REQUEST: %s", string(reqDump))
+	if tracing() {
+		reqDump, err := dumpRequest(req, payloadBytes)
+		if err != nil {
+			logger.Error.Println("Error dumping HTTP request:", err)
+		} else {
+			logger.Trace.Printf("REQUEST: %s", reqDump)
+		}
 	}
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return 0, []byte{}, err
+		return result, err
 	}
 
 	defer res.Body.Close()
+	result.code = res.StatusCode
+	result.header = res.Header
 	rbytes, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return 0, []byte{}, err
+		return result, err
 	}
+	result.body = rbytes
 
 	// No content type on 204 !!! Damnit
 	if res.StatusCode == http.StatusServiceUnavailable {
@@ -222,7 +285,7 @@ func (c *Client) request(method, path string, payload interface{}) (int, []byte,
 			deltaTime := parseTime.Sub(time.Now()).Round(1 * time.Minute)
 			plannedDate = strings.Trim(fmt.Sprintf("%v", deltaTime), "0s")
 		}
-		err = fmt.Errorf("%s: Potential date of service availability: %s (aka %s from now)", res.Status, retryAfter, plannedDate)
+		err = result.errorf("%s: Potential date of service availability: %s (aka %s from now)", res.Status, retryAfter, plannedDate)
 	} else if res.StatusCode == http.StatusUnauthorized {
 		extra := ""
 		var reason map[string]interface{}
@@ -236,21 +299,21 @@ func (c *Client) request(method, path string, payload interface{}) (int, []byte,
 				extra = fmt.Sprintf("%v, ", reason)
 			}
 		}
-		err = fmt.Errorf("%s: %splease make sure your SQSC_TOKEN environment variable is properly set", res.Status, extra)
+		err = result.errorf("%s: %splease make sure your SQSC_TOKEN environment variable is properly set", res.Status, extra)
 	} else if res.StatusCode != http.StatusNoContent && !strings.Contains(res.Header.Get("Content-Type"), ct) {
 		if res.StatusCode == http.StatusInternalServerError &&
 			strings.Contains(res.Header.Get("Content-Type"), "text/html") &&
 			(bytes.Contains(rbytes, []byte("<title>Action Controller: Exception caught</title>")) || len(rbytes) == 0) {
-			err = fmt.Errorf(
+			err = result.errorf(
 				"%s: Something went wrong on server side. Please report error to support@squarescale.com.",
 				res.Status,
 			)
 		} else {
 			if res.StatusCode == http.StatusGatewayTimeout {
 				logger.Warn.Printf("Got %q from %s at %s", res.Status, res.Header.Get("Server"), res.Header.Get("Date"))
-				err = fmt.Errorf("Got %q from %s at %s", res.Status, res.Header.Get("Server"), res.Header.Get("Date"))
+				err = result.errorf("Got %q from %s at %s", res.Status, res.Header.Get("Server"), res.Header.Get("Date"))
 			} else {
-				err = fmt.Errorf(
+				err = result.errorf(
 					"Invalid response return code (got %q instead of %+v/%+v) or content type (got %q instead of %q).\nDo you use the right value for -endpoint=%s ?",
 					res.Status, http.StatusNoContent, http.StatusInternalServerError, res.Header.Get("Content-Type"), ct, c.endpoint,
 				)
@@ -258,7 +321,7 @@ func (c *Client) request(method, path string, payload interface{}) (int, []byte,
 		}
 	}
 
-	return res.StatusCode, rbytes, err
+	return result, err
 }
 
 type RequestError struct {
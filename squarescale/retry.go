@@ -0,0 +1,147 @@
+package squarescale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry policy values
+const (
+	DefaultRetryMinBackoff    = 1 * time.Second
+	DefaultRetryMaxBackoff    = 30 * time.Second
+	DefaultRetryMaxRetryAfter = 2 * time.Minute
+)
+
+// RetryPolicy controls how API calls are retried on transient failures:
+// network errors, 429, 502, 503 and 504 responses
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries
+	MaxRetries int
+	// MinBackoff is the delay before the first retry, doubled on each retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Jitter randomizes each delay between half and all of its value
+	Jitter bool
+	// MaxRetryAfter is the longest Retry-After honored, the call fails
+	// right away when the server asks to wait longer
+	MaxRetryAfter time.Duration
+	// RetryNonIdempotent also retries POST and PATCH calls which may then be
+	// applied twice by the server
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy of new clients: no retry, with
+// sensible backoff values once MaxRetries is set
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MinBackoff:    DefaultRetryMinBackoff,
+		MaxBackoff:    DefaultRetryMaxBackoff,
+		Jitter:        true,
+		MaxRetryAfter: DefaultRetryMaxRetryAfter,
+	}
+}
+
+// SetRetryPolicy changes how the client retries transient failures
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// RetryPolicy returns how the client retries transient failures
+func (c *Client) RetryPolicy() RetryPolicy {
+	return c.retry
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isTransient(code int, err error) bool {
+	if code == 0 {
+		return err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay tells whether attempt should be followed by another one and
+// after which delay
+func (c *Client) retryDelay(method string, attempt int, code int, header http.Header, err error) (time.Duration, bool) {
+	policy := c.retry
+	if attempt > policy.MaxRetries || !isTransient(code, err) {
+		return 0, false
+	}
+
+	if !isIdempotent(method) && !policy.RetryNonIdempotent {
+		return 0, false
+	}
+
+	if c.Context().Err() != nil {
+		return 0, false
+	}
+
+	if retryAfter, ok := parseRetryAfter(header.Get("Retry-After")); ok {
+		if policy.MaxRetryAfter > 0 && retryAfter > policy.MaxRetryAfter {
+			return 0, false
+		}
+		return retryAfter, true
+	}
+
+	delay := policy.MinBackoff
+	for i := 1; i < attempt && (policy.MaxBackoff <= 0 || delay < policy.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	if policy.Jitter && delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	return delay, true
+}
+
+// parseRetryAfter decodes a Retry-After header given either in seconds or as
+// an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := time.Until(date)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
+
+func retryReason(code int, err error) string {
+	if code == 0 {
+		return err.Error()
+	}
+	return fmt.Sprintf("HTTP %d %s", code, http.StatusText(code))
+}
@@ -0,0 +1,167 @@
+package squarescale_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+func TestRetry(t *testing.T) {
+	t.Run("Test no retry by default", NoRetryByDefault)
+	t.Run("Test retry of transient failures on idempotent calls", RetryOnIdempotentCalls)
+	t.Run("Test retries exhausted", RetriesExhausted)
+	t.Run("Test no retry of non idempotent calls", NoRetryOnNonIdempotentCalls)
+	t.Run("Test retry of non idempotent calls when opted in", RetryOnNonIdempotentCallsOptIn)
+	t.Run("Test Retry-After longer than the maximum", RetryAfterTooLong)
+}
+
+// flakyServer answers 503 with retryAfter to the first failures requests and
+// an empty project list afterwards
+func flakyServer(t *testing.T, failures int, retryAfter string) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	return server, &calls
+}
+
+func fastRetryPolicy(maxRetries int) squarescale.RetryPolicy {
+	policy := squarescale.DefaultRetryPolicy()
+	policy.MaxRetries = maxRetries
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func NoRetryByDefault(t *testing.T) {
+	// given
+	server, calls := flakyServer(t, 1, "")
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+
+	// when
+	_, err := cli.ListProjects()
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "503 Service Unavailable") {
+		t.Fatalf("Expect a 503 error, got `%v`", err)
+	}
+
+	if *calls != 1 {
+		t.Fatalf("Expect 1 call, got `%d`", *calls)
+	}
+}
+
+func RetryOnIdempotentCalls(t *testing.T) {
+	// given
+	server, calls := flakyServer(t, 2, "0")
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+	cli.SetRetryPolicy(fastRetryPolicy(3))
+
+	// when
+	_, err := cli.ListProjects()
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if *calls != 3 {
+		t.Fatalf("Expect 3 calls, got `%d`", *calls)
+	}
+}
+
+func RetriesExhausted(t *testing.T) {
+	// given
+	server, calls := flakyServer(t, 10, "")
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+	cli.SetRetryPolicy(fastRetryPolicy(2))
+
+	// when
+	_, err := cli.ListProjects()
+
+	// then
+	if err == nil {
+		t.Fatalf("Expect an error once retries are exhausted")
+	}
+
+	if *calls != 3 {
+		t.Fatalf("Expect 3 calls, got `%d`", *calls)
+	}
+}
+
+func NoRetryOnNonIdempotentCalls(t *testing.T) {
+	// given
+	server, calls := flakyServer(t, 1, "")
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+	cli.SetRetryPolicy(fastRetryPolicy(3))
+
+	// when
+	payload := squarescale.JSONObject{"name": "project-test", "credential_name": "aws-production"}
+	_, err := cli.CreateProject(&payload)
+
+	// then
+	if err == nil {
+		t.Fatalf("Expect an error on non idempotent call")
+	}
+
+	if *calls != 1 {
+		t.Fatalf("Expect 1 call, got `%d`", *calls)
+	}
+}
+
+func RetryOnNonIdempotentCallsOptIn(t *testing.T) {
+	// given
+	server, calls := flakyServer(t, 1, "")
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+	policy := fastRetryPolicy(3)
+	policy.RetryNonIdempotent = true
+	cli.SetRetryPolicy(policy)
+
+	// when
+	payload := squarescale.JSONObject{"name": "project-test", "credential_name": "aws-production"}
+	cli.CreateProject(&payload)
+
+	// then
+	if *calls != 2 {
+		t.Fatalf("Expect 2 calls, got `%d`", *calls)
+	}
+}
+
+func RetryAfterTooLong(t *testing.T) {
+	// given
+	server, calls := flakyServer(t, 1, "3600")
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, "some-token")
+	cli.SetRetryPolicy(fastRetryPolicy(3))
+
+	// when
+	_, err := cli.ListProjects()
+
+	// then
+	if err == nil {
+		t.Fatalf("Expect an error when Retry-After exceeds the maximum")
+	}
+
+	if *calls != 1 {
+		t.Fatalf("Expect 1 call, got `%d`", *calls)
+	}
+}
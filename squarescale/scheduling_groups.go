@@ -27,22 +27,22 @@ func (c *Client) AddSchedulingGroup(projectUUID string, name string) (Scheduling
 	payload := JSONObject{
 		"name": name,
 	}
-	code, body, err := c.post("/projects/"+projectUUID+"/scheduling_groups", &payload)
+	res, err := c.post("/projects/"+projectUUID+"/scheduling_groups", &payload)
 	if err != nil {
 		return newSchedulingGroup, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 	case http.StatusNotFound:
-		return newSchedulingGroup, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return newSchedulingGroup, res.errorf("Project '%s' does not exist", projectUUID)
 	case http.StatusConflict:
-		return newSchedulingGroup, fmt.Errorf("Scheduling group already exist on project '%s': %s", projectUUID, name)
+		return newSchedulingGroup, res.errorf("Scheduling group already exist on project '%s': %s", projectUUID, name)
 	default:
-		return newSchedulingGroup, unexpectedHTTPError(code, body)
+		return newSchedulingGroup, res.unexpectedError()
 	}
 
-	if err := json.Unmarshal(body, &newSchedulingGroup); err != nil {
+	if err := json.Unmarshal(res.body, &newSchedulingGroup); err != nil {
 		return newSchedulingGroup, err
 	}
 
@@ -51,22 +51,22 @@ func (c *Client) AddSchedulingGroup(projectUUID string, name string) (Scheduling
 
 // DeleteSchedulingGroup delete a existing scheduling group
 func (c *Client) DeleteSchedulingGroup(projectUUID string, name string) error {
-	code, body, err := c.delete("/projects/" + projectUUID + "/scheduling_groups/" + name)
+	res, err := c.delete("/projects/" + projectUUID + "/scheduling_groups/" + name)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		if fmt.Sprintf("%s", body) == `{"error":"Couldn't find SchedulingGroup with [WHERE \"scheduling_groups\".\"cluster_id\" = $1 AND \"scheduling_groups\".\"name\" = $2]"}` {
-			return fmt.Errorf("Scheduling group '%s' does not exist", name)
+		if fmt.Sprintf("%s", res.body) == `{"error":"Couldn't find SchedulingGroup with [WHERE \"scheduling_groups\".\"cluster_id\" = $1 AND \"scheduling_groups\".\"name\" = $2]"}` {
+			return res.errorf("Scheduling group '%s' does not exist", name)
 		}
-		return fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return res.errorf("Project '%s' does not exist", projectUUID)
 	case http.StatusBadRequest:
-		return fmt.Errorf("Deploy probably in progress")
+		return res.errorf("Deploy probably in progress")
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -74,22 +74,22 @@ func (c *Client) DeleteSchedulingGroup(projectUUID string, name string) error {
 
 // GetSchedulingGroups gets all the scheduling groups attached to a Project
 func (c *Client) GetSchedulingGroups(projectUUID string) ([]SchedulingGroup, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/scheduling_groups")
+	res, err := c.get("/projects/" + projectUUID + "/scheduling_groups")
 	if err != nil {
 		return []SchedulingGroup{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []SchedulingGroup{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []SchedulingGroup{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []SchedulingGroup{}, unexpectedHTTPError(code, body)
+		return []SchedulingGroup{}, res.unexpectedError()
 	}
 
 	var schedulingGroupsByID []SchedulingGroup
 
-	if err := json.Unmarshal(body, &schedulingGroupsByID); err != nil {
+	if err := json.Unmarshal(res.body, &schedulingGroupsByID); err != nil {
 		return []SchedulingGroup{}, err
 	}
 
@@ -109,7 +109,7 @@ func (c *Client) GetSchedulingGroupInfo(projectUUID string, name string) (Schedu
 		}
 	}
 
-	return SchedulingGroup{}, fmt.Errorf("Scheduling group '%s' not found for project '%s'", name, projectUUID)
+	return SchedulingGroup{}, notFoundError("/projects/"+projectUUID+"/scheduling_groups", "Scheduling group '%s' not found for project '%s'", name, projectUUID)
 }
 
 func (c *Client) GetSchedulingGroupServices(schedulingGroup SchedulingGroup, concatSep string) string {
@@ -149,17 +149,17 @@ func (c *Client) PutSchedulingGroupsNodes(projectUUID, schedulingGroupName, payl
 }
 
 func (c *Client) sendSchedulingGroupsPut(projectUUID, schedulingGroupName string, payload *JSONObject) error {
-	code, body, err := c.put("/projects/" + projectUUID + "/scheduling_groups/" + schedulingGroupName, payload)
+	res, err := c.put("/projects/" + projectUUID + "/scheduling_groups/" + schedulingGroupName, payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
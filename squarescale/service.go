@@ -18,47 +18,55 @@ type ServiceEnv struct {
 	Predefined bool   `json:"predefined"`
 }
 
-// TODO: add missing allocations, docker_image, custom_environment -> environment, instances_count
+// TODO: add missing allocations, custom_environment -> environment, instances_count
 // refresh_callbacks, status
 // TODO: see why 2 different structs are used ???
 
 // Service describes a project container as returned by the SquareScale API
 type Service struct {
-	ID                  int               `json:"container_id"`
-	Name                string            `json:"name"`
-	RunCommand          string            `json:"run_command"` // is array in the next structure
-	Entrypoint          string            `json:"entrypoint"`
-	Running             int               `json:"running"`
-	Size                int               `json:"size"`
-	WebPort             int               `json:"web_port"`
-	RefreshCallbacks    []string          `json:"refresh_callbacks"`
-	Limits              ServiceLimits     `json:"limits"`
-	CustomEnv           []ServiceEnv      `json:"custom_environment"`
-	SchedulingGroups    []SchedulingGroup `json:"scheduling_groups"`
-	DockerCapabilities  []string          `json:"docker_capabilities"`
-	DockerDevices       []DockerDevice    `json:"docker_devices"`
-	AutoStart           bool              `json:"auto_start"`
-	MaxClientDisconnect string            `json:"max_client_disconnect"` // is int in the next structure
-	Volumes             []VolumeToBind    `json:"volumes"`
+	ID                  int                `json:"container_id"`
+	Name                string             `json:"name"`
+	RunCommand          string             `json:"run_command"` // is array in the next structure
+	Entrypoint          string             `json:"entrypoint"`
+	Running             int                `json:"running"`
+	Size                int                `json:"size"`
+	WebPort             int                `json:"web_port"`
+	RefreshCallbacks    []string           `json:"refresh_callbacks"`
+	Limits              ServiceLimits      `json:"limits"`
+	CustomEnv           []ServiceEnv       `json:"custom_environment"`
+	SchedulingGroups    []SchedulingGroup  `json:"scheduling_groups"`
+	DockerCapabilities  []string           `json:"docker_capabilities"`
+	DockerDevices       []DockerDevice     `json:"docker_devices"`
+	AutoStart           bool               `json:"auto_start"`
+	MaxClientDisconnect string             `json:"max_client_disconnect"` // is int in the next structure
+	Volumes             []VolumeToBind     `json:"volumes"`
+	DockerImage         ServiceDockerImage `json:"docker_image"`
+}
+
+// ServiceDockerImage describes the Docker image of a service
+type ServiceDockerImage struct {
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
 }
 
 type ServiceBody struct {
-	ID                  int               `json:"container_id"`
-	Name                string            `json:"name"`
-	RunCommand          []string          `json:"run_command"` // is not an array in the previous structure
-	Entrypoint          string            `json:"entrypoint"`
-	Running             int               `json:"running"`
-	Size                int               `json:"size"`
-	WebPort             int               `json:"web_port"`
-	RefreshCallbacks    []string          `json:"refresh_callbacks"`
-	Limits              ServiceLimits     `json:"limits"`
-	CustomEnv           []ServiceEnv      `json:"custom_environment"`
-	SchedulingGroups    []SchedulingGroup `json:"scheduling_groups"`
-	DockerCapabilities  []string          `json:"docker_capabilities"`
-	DockerDevices       []DockerDevice    `json:"docker_devices"`
-	AutoStart           bool              `json:"auto_start"`
-	MaxClientDisconnect int               `json:"max_client_disconnect"` // is string in previous structure
-	Volumes             []VolumeToBind    `json:"volumes"`
+	ID                  int                `json:"container_id"`
+	Name                string             `json:"name"`
+	RunCommand          []string           `json:"run_command"` // is not an array in the previous structure
+	Entrypoint          string             `json:"entrypoint"`
+	Running             int                `json:"running"`
+	Size                int                `json:"size"`
+	WebPort             int                `json:"web_port"`
+	RefreshCallbacks    []string           `json:"refresh_callbacks"`
+	Limits              ServiceLimits      `json:"limits"`
+	CustomEnv           []ServiceEnv       `json:"custom_environment"`
+	SchedulingGroups    []SchedulingGroup  `json:"scheduling_groups"`
+	DockerCapabilities  []string           `json:"docker_capabilities"`
+	DockerDevices       []DockerDevice     `json:"docker_devices"`
+	AutoStart           bool               `json:"auto_start"`
+	MaxClientDisconnect int                `json:"max_client_disconnect"` // is string in previous structure
+	Volumes             []VolumeToBind     `json:"volumes"`
+	DockerImage         ServiceDockerImage `json:"docker_image"`
 }
 
 func (c *Service) SetEnv(path string) error {
@@ -119,22 +127,22 @@ type ServiceLimits struct {
 // TODO: get /projects/" + projectUUID + "/project_info"
 // GetContainers gets all the services attached to a Project
 func (c *Client) GetServices(projectUUID string) ([]Service, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/services")
+	res, err := c.get("/projects/" + projectUUID + "/services")
 	if err != nil {
 		return []Service{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []Service{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []Service{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []Service{}, unexpectedHTTPError(code, body)
+		return []Service{}, res.unexpectedError()
 	}
 
 	var servicesBody []ServiceBody
 
-	if err := json.Unmarshal(body, &servicesBody); err != nil {
+	if err := json.Unmarshal(res.body, &servicesBody); err != nil {
 		return []Service{}, err
 	}
 
@@ -158,6 +166,7 @@ func (c *Client) GetServices(projectUUID string) ([]Service, error) {
 			AutoStart:           c.AutoStart,
 			MaxClientDisconnect: strconv.Itoa(c.MaxClientDisconnect),
 			Volumes:             c.Volumes,
+			DockerImage:         c.DockerImage,
 		}
 		services = append(services, *service)
 	}
@@ -167,13 +176,13 @@ func (c *Client) GetServices(projectUUID string) ([]Service, error) {
 
 // GetServiceInfo get the service of a project based on its name.
 func (c *Client) ScheduleService(projectUUID, name string) error {
-	code, body, err := c.post(fmt.Sprintf("/projects/%s/services/%s/schedule", projectUUID, name), nil)
+	res, err := c.post(fmt.Sprintf("/projects/%s/services/%s/schedule", projectUUID, name), nil)
 	if err != nil {
 		return err
 	}
 
-	if code != http.StatusOK {
-		return unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -194,7 +203,7 @@ func (c *Client) GetServiceInfo(projectUUID, name string) (Service, error) {
 		}
 	}
 
-	return Service{}, fmt.Errorf("Service '%s' not found for project '%s'", name, projectUUID)
+	return Service{}, notFoundError("/projects/"+projectUUID+"/services", "Service '%s' not found for project '%s'", name, projectUUID)
 }
 
 // ConfigService calls the API to update the number of instances and update command.
@@ -203,6 +212,9 @@ func (c *Client) ConfigService(service Service) error {
 	if len(service.RunCommand) != 0 {
 		svcConf["run_command"] = service.RunCommand
 	}
+	if len(service.Entrypoint) != 0 {
+		svcConf["entrypoint"] = service.Entrypoint
+	}
 	if service.Size > 0 {
 		svcConf["size"] = service.Size
 	}
@@ -238,35 +250,35 @@ func (c *Client) ConfigService(service Service) error {
 
 	payload := &JSONObject{"container": svcConf}
 	logger.Debug.Println("Json payload : ", payload)
-	code, body, err := c.put(fmt.Sprintf("/containers/%d", service.ID), payload)
+	res, err := c.put(fmt.Sprintf("/containers/%d", service.ID), payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		return errors.New("Container does not exist")
+		return res.errorf("Container does not exist")
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
 
 func (c *Client) DeleteService(service Service) error {
 	url := fmt.Sprintf("/containers/%d", service.ID)
-	code, body, err := c.delete(url)
+	res, err := c.delete(url)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	// strange reading this but seems like OK does continue without any return
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return fmt.Errorf("service with id '%d' does not exist", service.ID)
+		return res.errorf("service with id '%d' does not exist", service.ID)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 
 	return nil
@@ -284,16 +296,16 @@ func getSchedulingGroupsIds(schedulingGroups []SchedulingGroup) []int {
 
 // AddService asks the SquareScale service to attach an image to the project.
 func (c *Client) AddService(projectUUID string, payload JSONObject) error {
-	code, body, err := c.post("/projects/"+projectUUID+"/docker_images", &payload)
+	res, err := c.post("/projects/"+projectUUID+"/docker_images", &payload)
 	if err != nil {
-		return fmt.Errorf("Cannot add docker image '%v' to project '%s' (%d %s)\n\t%s", payload["docker_image"], projectUUID, code, http.StatusText(code), err)
+		return fmt.Errorf("Cannot add docker image '%v' to project '%s' (%d %s)\n\t%s", payload["docker_image"], projectUUID, res.code, http.StatusText(res.code), err)
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 		return nil
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
 
@@ -2,7 +2,6 @@ package squarescale
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -17,18 +16,18 @@ type User struct {
 
 // ValidateToken asks SquareScale service for token validity. Returns nil if user is authorized.
 func (c *Client) ValidateToken() (*User, error) {
-	code, body, err := c.get("/me")
+	res, err := c.get("/me")
 	if err != nil {
 		return nil, err
 	}
 
-	if code != http.StatusOK {
-		return nil, fmt.Errorf("You're not logged in, please run login command")
-		//return nil, unexpectedHTTPError(code, body)
+	if res.code != http.StatusOK {
+		return nil, res.errorf("You're not logged in, please run login command")
+		//return nil, res.unexpectedError()
 	}
 
 	var userJSON User
-	err = json.Unmarshal(body, &userJSON)
+	err = json.Unmarshal(res.body, &userJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -29,22 +29,22 @@ type VolumeToBind struct {
 
 // GetVolumes gets all the volumes attached to a Project
 func (c *Client) GetVolumes(projectUUID string) ([]Volume, error) {
-	code, body, err := c.get("/projects/" + projectUUID + "/volumes")
+	res, err := c.get("/projects/" + projectUUID + "/volumes")
 	if err != nil {
 		return []Volume{}, err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return []Volume{}, fmt.Errorf("Project '%s' does not exist", projectUUID)
+		return []Volume{}, res.errorf("Project '%s' does not exist", projectUUID)
 	default:
-		return []Volume{}, unexpectedHTTPError(code, body)
+		return []Volume{}, res.unexpectedError()
 	}
 
 	var volumesByID []Volume
 
-	if err := json.Unmarshal(body, &volumesByID); err != nil {
+	if err := json.Unmarshal(res.body, &volumesByID); err != nil {
 		return []Volume{}, err
 	}
 
@@ -65,21 +65,27 @@ func (c *Client) GetVolumeInfo(projectUUID, name string) (Volume, error) {
 		}
 	}
 
-	return Volume{}, fmt.Errorf("Volume '%s' not found for project '%s'", name, projectUUID)
+	return Volume{}, notFoundError("/projects/"+projectUUID+"/volumes", "Volume '%s' not found for project '%s'", name, projectUUID)
 }
 
 // WaitVolume wait the volume of a project based on its name.
 func (c *Client) WaitVolume(projectUUID, name string, timeToWait int64) (Volume, error) {
-	volume, err := c.GetVolumeInfo(projectUUID, name)
-	if err != nil {
-		return volume, err
-	}
+	opts := WaitOptions{Interval: time.Duration(timeToWait) * time.Second}
+	return c.WaitVolumeWithOptions(projectUUID, name, opts)
+}
 
-	for volume.Status != "provisionned" && err == nil {
-		time.Sleep(time.Duration(timeToWait) * time.Second)
-		volume, err = c.GetVolumeInfo(projectUUID, name)
-		logger.Debug.Println("volume status update: ", volume.Name)
-	}
+// WaitVolumeWithOptions wait until the volume is provisionned
+func (c *Client) WaitVolumeWithOptions(projectUUID, name string, opts WaitOptions) (Volume, error) {
+	var volume Volume
+	_, err := c.Wait(projectUUID, opts, func(client *Client) (string, bool, error) {
+		var err error
+		volume, err = client.GetVolumeInfo(projectUUID, name)
+		if err != nil {
+			return "", false, err
+		}
+		logger.Debug.Println("volume status update: ", volume.Name, " Status: ", volume.Status)
+		return volume.Status, volume.Status == "provisionned", nil
+	})
 
 	return volume, err
 }
@@ -87,21 +93,21 @@ func (c *Client) WaitVolume(projectUUID, name string, timeToWait int64) (Volume,
 // DeleteVolume delete volume of a project based on its name.
 func (c *Client) DeleteVolume(projectUUID string, volume string) error {
 	url := fmt.Sprintf("/projects/%s/volumes/%s", projectUUID, volume)
-	code, body, err := c.delete(url)
+	res, err := c.delete(url)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusOK:
 		return nil
 	case http.StatusNotFound:
-		if fmt.Sprintf("%s", body) == `{"error":"Couldn't find Volume with [WHERE \"volumes\".\"cluster_id\" = $1 AND \"volumes\".\"name\" = $2]"}` {
-			return fmt.Errorf("{\"error\":\"No volume found for name: %s\"}", volume)
+		if fmt.Sprintf("%s", res.body) == `{"error":"Couldn't find Volume with [WHERE \"volumes\".\"cluster_id\" = $1 AND \"volumes\".\"name\" = $2]"}` {
+			return res.errorf("{\"error\":\"No volume found for name: %s\"}", volume)
 		}
-		return fmt.Errorf("%s", body)
+		return res.errorf("%s", res.body)
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
 
@@ -115,19 +121,19 @@ func (c *Client) AddVolume(projectUUID string, name string, size int, volumeType
 	}
 
 	url := fmt.Sprintf("/projects/%s/volumes", projectUUID)
-	code, body, err := c.post(url, &payload)
+	res, err := c.post(url, &payload)
 	if err != nil {
 		return err
 	}
 
-	switch code {
+	switch res.code {
 	case http.StatusCreated:
 		return nil
 	case http.StatusConflict:
-		return fmt.Errorf("Volume already exist on project '%s': %s", projectUUID, name)
+		return res.errorf("Volume already exist on project '%s': %s", projectUUID, name)
 	case http.StatusNotFound:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	default:
-		return unexpectedHTTPError(code, body)
+		return res.unexpectedError()
 	}
 }
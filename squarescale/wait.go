@@ -0,0 +1,145 @@
+package squarescale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/squarescale/logger"
+)
+
+// Default polling cadence of the waiters
+const (
+	DefaultWaitInterval    = 5 * time.Second
+	DefaultWaitMaxInterval = 30 * time.Second
+	DefaultWaitFactor      = 1.5
+)
+
+// WaitOptions tunes how a waiter polls the SquareScale API
+type WaitOptions struct {
+	// Context cancels the wait when done, the client context is used if nil
+	Context context.Context
+	// Timeout is the overall deadline of the wait, no deadline if 0
+	Timeout time.Duration
+	// Interval is the delay before the second poll
+	Interval time.Duration
+	// MaxInterval caps the delay between two polls, no cap if 0
+	MaxInterval time.Duration
+	// Factor multiplies the delay after each poll, the delay is constant if <= 1
+	Factor float64
+	// Progress is called each time the observed status changes
+	Progress func(status string)
+	// Push polls again as soon as an event is received on the project
+	// ActionCable channel, on top of the regular polling
+	Push bool
+}
+
+// DefaultWaitOptions returns the options used by the command line waiters
+func DefaultWaitOptions() WaitOptions {
+	return WaitOptions{
+		Interval:    DefaultWaitInterval,
+		MaxInterval: DefaultWaitMaxInterval,
+		Factor:      DefaultWaitFactor,
+	}
+}
+
+// WaitFunc checks the awaited resource through client, which is bound to the
+// wait deadline, it returns the resource current status and whether the wait
+// is over
+type WaitFunc func(client *Client) (status string, done bool, err error)
+
+// WaitTimeoutError is returned when a wait exceeds WaitOptions.Timeout
+type WaitTimeoutError struct {
+	Timeout time.Duration
+	Status  string
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("Timeout after %s waiting for completion (last status: %s)", e.Timeout, e.Status)
+}
+
+// Wait calls check until the wait is over, check fails, the timeout expires
+// or the context is cancelled. It returns the last observed status.
+func (c *Client) Wait(projectUUID string, opts WaitOptions, check WaitFunc) (string, error) {
+	parent := opts.Context
+	if parent == nil {
+		parent = c.Context()
+	}
+
+	ctx := parent
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, opts.Timeout)
+		defer cancel()
+	}
+
+	var push <-chan Event
+	if opts.Push {
+		sub, err := c.Subscribe(projectUUID, ProjectChannel)
+		if err != nil {
+			logger.Debug.Println("Unable to subscribe to project events, polling only: ", err)
+		} else {
+			defer sub.Close()
+			push = sub.Events
+		}
+	}
+
+	client := c.WithContext(ctx)
+	interval := opts.Interval
+	var status string
+	for {
+		if ctx.Err() != nil {
+			return status, waitError(ctx, parent, opts.Timeout, status)
+		}
+
+		current, done, err := check(client)
+		if err != nil {
+			if ctx.Err() != nil {
+				return current, waitError(ctx, parent, opts.Timeout, status)
+			}
+			return current, err
+		}
+		if current != status {
+			status = current
+			if opts.Progress != nil {
+				opts.Progress(status)
+			}
+		}
+		if done {
+			return status, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, waitError(ctx, parent, opts.Timeout, status)
+		case <-timer.C:
+			interval = nextWaitInterval(interval, opts)
+		case _, ok := <-push:
+			timer.Stop()
+			if !ok {
+				push = nil
+			}
+			interval = opts.Interval
+		}
+	}
+}
+
+func waitError(ctx, parent context.Context, timeout time.Duration, status string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil {
+		return &WaitTimeoutError{Timeout: timeout, Status: status}
+	}
+	return ctx.Err()
+}
+
+func nextWaitInterval(interval time.Duration, opts WaitOptions) time.Duration {
+	if opts.Factor > 1 {
+		interval = time.Duration(float64(interval) * opts.Factor)
+	}
+	if opts.MaxInterval > 0 && interval > opts.MaxInterval {
+		interval = opts.MaxInterval
+	}
+	return interval
+}
@@ -0,0 +1,337 @@
+package squarescale_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/squarescale/squarescale-cli/squarescale"
+)
+
+func TestWait(t *testing.T) {
+	t.Run("Nominal case on Wait", nominalCaseOnWait)
+	t.Run("Test check error on Wait", CheckErrorOnWait)
+	t.Run("Test timeout on Wait", TimeoutOnWait)
+	t.Run("Test cancelled context on Wait", CancelledContextOnWait)
+	t.Run("Test push events on Wait", PushEventsOnWait)
+	t.Run("Test push unavailable on Wait", PushUnavailableOnWait)
+	t.Run("Test polling only on Wait", PollingOnlyOnWait)
+
+	// WaitProjectStatus
+	t.Run("Test infrastructure error on WaitProjectStatus", InfraErrorOnWaitProjectStatus)
+
+	// WaitProjectDeleted
+	t.Run("Nominal case on WaitProjectDeleted", nominalCaseOnWaitProjectDeleted)
+}
+
+func nominalCaseOnWait(t *testing.T) {
+	// given
+	cli := squarescale.NewClient("http://localhost", "some-token")
+	statuses := []string{"provisionning", "provisionning", "configuring", "ok"}
+	var progress []string
+	calls := 0
+
+	opts := squarescale.WaitOptions{
+		Interval:    time.Millisecond,
+		MaxInterval: 4 * time.Millisecond,
+		Factor:      2,
+		Progress: func(status string) {
+			progress = append(progress, status)
+		},
+	}
+
+	// when
+	status, err := cli.Wait("", opts, func(*squarescale.Client) (string, bool, error) {
+		status := statuses[calls]
+		calls++
+		return status, status == "ok", nil
+	})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if status != "ok" {
+		t.Fatalf("Expect status `ok`, got `%s`", status)
+	}
+
+	if calls != 4 {
+		t.Fatalf("Expect 4 checks, got `%d`", calls)
+	}
+
+	expectedProgress := []string{"provisionning", "configuring", "ok"}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Fatalf("Expect progress `%v`, got `%v`", expectedProgress, progress)
+	}
+}
+
+func CheckErrorOnWait(t *testing.T) {
+	// given
+	cli := squarescale.NewClient("http://localhost", "some-token")
+	expectedError := "something went wrong"
+
+	// when
+	_, err := cli.Wait("", squarescale.WaitOptions{}, func(*squarescale.Client) (string, bool, error) {
+		return "", false, errors.New(expectedError)
+	})
+
+	// then
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, err)
+	}
+}
+
+func TimeoutOnWait(t *testing.T) {
+	// given
+	cli := squarescale.NewClient("http://localhost", "some-token")
+	opts := squarescale.WaitOptions{
+		Timeout:  20 * time.Millisecond,
+		Interval: time.Millisecond,
+	}
+
+	// when
+	status, err := cli.Wait("", opts, func(*squarescale.Client) (string, bool, error) {
+		return "provisionning", false, nil
+	})
+
+	// then
+	var timeoutErr *squarescale.WaitTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expect a WaitTimeoutError, got `%v`", err)
+	}
+
+	if status != "provisionning" || timeoutErr.Status != "provisionning" {
+		t.Fatalf("Expect last status `provisionning`, got `%s`", status)
+	}
+
+	expectedError := "Timeout after 20ms waiting for completion (last status: provisionning)"
+	if err.Error() != expectedError {
+		t.Fatalf("Expected error message:\n`%s`\nGot:\n`%s`", expectedError, err)
+	}
+}
+
+func CancelledContextOnWait(t *testing.T) {
+	// given
+	cli := squarescale.NewClient("http://localhost", "some-token")
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := squarescale.WaitOptions{
+		Context:  ctx,
+		Timeout:  time.Minute,
+		Interval: time.Minute,
+	}
+
+	// when
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := cli.Wait("", opts, func(*squarescale.Client) (string, bool, error) {
+		return "provisionning", false, nil
+	})
+
+	// then
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expect context.Canceled, got `%v`", err)
+	}
+}
+
+func PushEventsOnWait(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	mux := http.NewServeMux()
+	mux.Handle("/cable", cableHandler(t, token, "confirm_subscription", []string{
+		`{"type": "infra_status", "status": "ok"}`,
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	defer cli.Close()
+
+	var calls int32
+	opts := squarescale.WaitOptions{
+		Timeout:  5 * time.Second,
+		Interval: time.Minute,
+		Push:     true,
+	}
+
+	// when
+	status, err := cli.Wait(projectUUID, opts, func(*squarescale.Client) (string, bool, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return "provisionning", false, nil
+		}
+		return "ok", true, nil
+	})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if status != "ok" {
+		t.Fatalf("Expect status `ok`, got `%s`", status)
+	}
+}
+
+func PushUnavailableOnWait(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	defer cli.Close()
+
+	var calls int32
+	opts := squarescale.WaitOptions{
+		Timeout:  5 * time.Second,
+		Interval: 10 * time.Millisecond,
+		Push:     true,
+	}
+
+	// when
+	status, err := cli.Wait(projectUUID, opts, func(*squarescale.Client) (string, bool, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return "provisionning", false, nil
+		}
+		return "ok", true, nil
+	})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if status != "ok" {
+		t.Fatalf("Expect status `ok`, got `%s`", status)
+	}
+}
+
+func PollingOnlyOnWait(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "5fb75c1d-90a4-4b34-891f-a7481fa04afe"
+
+	var cableCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&cableCalls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cli := squarescale.NewClient(server.URL, token)
+	defer cli.Close()
+
+	var calls int32
+	opts := squarescale.DefaultWaitOptions()
+	opts.Timeout = 5 * time.Second
+	opts.Interval = 10 * time.Millisecond
+
+	// when
+	status, err := cli.Wait(projectUUID, opts, func(*squarescale.Client) (string, bool, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return "provisionning", false, nil
+		}
+		return "ok", true, nil
+	})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if status != "ok" || atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("Expect status `ok` after 3 polls, got `%s` after %d", status, calls)
+	}
+
+	if atomic.LoadInt32(&cableCalls) != 0 {
+		t.Fatalf("Expect no event stream to be opened without Push")
+	}
+}
+
+func InfraErrorOnWaitProjectStatus(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "8cfe8f68-cad5-4157-b8a6-d9efa12caf0e"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkAuthorization(t, r.Header.Get("Authorization"), token)
+
+		var resBody string
+		switch r.URL.Path {
+		case "/projects/" + projectUUID:
+			resBody = fmt.Sprintf(`{"name": "tera-project", "uuid": "%s", "infra_status": "error"}`, projectUUID)
+		case "/projects/" + projectUUID + "/infrastructure_actions":
+			resBody = `[{"log": "terraform apply failed"}]`
+		default:
+			t.Fatalf("Unexpected path `%s`", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(resBody))
+	}))
+
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, token)
+
+	// when
+	status, err := cli.WaitProjectStatus(projectUUID, []string{"ok"}, squarescale.WaitOptions{})
+
+	// then
+	expectedError := "terraform apply failed"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expect error `%s`, got `%v`", expectedError, err)
+	}
+
+	if status != "error" {
+		t.Fatalf("Expect status `error`, got `%s`", status)
+	}
+}
+
+func nominalCaseOnWaitProjectDeleted(t *testing.T) {
+	// given
+	token := "some-token"
+	projectUUID := "8cfe8f68-cad5-4157-b8a6-d9efa12caf0e"
+	httptestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkPath(t, "/projects/"+projectUUID, r.URL.Path)
+		checkAuthorization(t, r.Header.Get("Authorization"), token)
+
+		httptestCount++
+
+		w.Header().Set("Content-Type", "application/json")
+		if httptestCount < 3 {
+			w.Write([]byte(fmt.Sprintf(`{"name": "tera-project", "uuid": "%s", "infra_status": "no_infra"}`, projectUUID)))
+		} else {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"error":"Not found"}`))
+		}
+	}))
+
+	defer server.Close()
+	cli := squarescale.NewClient(server.URL, token)
+
+	// when
+	err := cli.WaitProjectDeleted(projectUUID, squarescale.WaitOptions{})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	if httptestCount != 3 {
+		t.Fatalf("Expect 3 calls, got `%d`", httptestCount)
+	}
+}
@@ -0,0 +1,96 @@
+package tokenstore
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Environment variables giving the token, in order of precedence, before
+// the credential store
+const (
+	TokenEnv        = "SQSC_TOKEN"
+	TokenFileEnv    = "SQSC_TOKEN_FILE"
+	TokenCommandEnv = "SQSC_TOKEN_COMMAND"
+)
+
+var (
+	commandTokensMutex sync.Mutex
+	// commandTokens caches the output of the token commands for the
+	// lifetime of the process
+	commandTokens = map[string]string{}
+)
+
+// envToken returns the token given by the environment along with its
+// source, empty when there is none.
+func envToken() (string, string, error) {
+	if token := os.Getenv(TokenEnv); token != "" {
+		return token, "environment variable " + TokenEnv, nil
+	}
+
+	if path := os.Getenv(TokenFileEnv); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("Could not read the token file given by %s: %s", TokenFileEnv, err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", "", fmt.Errorf("Token file %s given by %s is empty", path, TokenFileEnv)
+		}
+		return token, fmt.Sprintf("file %s (%s)", path, TokenFileEnv), nil
+	}
+
+	if command := os.Getenv(TokenCommandEnv); command != "" {
+		token, err := commandToken(command)
+		if err != nil {
+			return "", "", err
+		}
+		return token, "command of " + TokenCommandEnv, nil
+	}
+
+	return "", "", nil
+}
+
+// ExternalToken tells whether the token is given by SQSC_TOKEN_FILE or
+// SQSC_TOKEN_COMMAND, which keep managing it: such a token must not be
+// copied to the token store.
+func ExternalToken() bool {
+	if os.Getenv(TokenEnv) != "" {
+		return false
+	}
+	return os.Getenv(TokenFileEnv) != "" || os.Getenv(TokenCommandEnv) != ""
+}
+
+// commandToken runs the token command through the shell once per process
+// and returns its trimmed output.
+func commandToken(command string) (string, error) {
+	commandTokensMutex.Lock()
+	defer commandTokensMutex.Unlock()
+
+	if token, ok := commandTokens[command]; ok {
+		return token, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	c := exec.Command("sh", "-c", command)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("Command given by %s failed: %s", TokenCommandEnv, message)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("Command given by %s printed no token", TokenCommandEnv)
+	}
+
+	commandTokens[command] = token
+	return token, nil
+}
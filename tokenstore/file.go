@@ -0,0 +1,180 @@
+package tokenstore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/squarescale/squarescale-cli/config"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PassphraseEnv is the environment variable holding the passphrase of the
+// encrypted file credential store
+const PassphraseEnv = "SQSC_CREDENTIAL_PASSPHRASE"
+
+// FileEnv is the environment variable overriding the path of the encrypted
+// file credential store
+const FileEnv = "SQSC_CREDENTIAL_FILE"
+
+// fileMagic starts the encrypted files, followed by the salt of the key, the
+// nonce and the sealed tokens
+var fileMagic = []byte("SQSC-CREDENTIALS-1\n")
+
+const (
+	saltSize = 16
+	keySize  = 32
+)
+
+func credentialsFile() string {
+	if path := os.Getenv(FileEnv); path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(config.Path()), "credentials")
+}
+
+// FileStore stores the tokens in a file encrypted with AES-GCM, the key being
+// derived from a passphrase with scrypt. The file is locked while updated,
+// and written atomically with 0600 permissions.
+type FileStore struct {
+	Path       string
+	Passphrase string
+}
+
+// NewFileStore returns the store of the encrypted file at path.
+func NewFileStore(path, passphrase string) *FileStore {
+	return &FileStore{Path: path, Passphrase: passphrase}
+}
+
+func (s *FileStore) String() string {
+	return "encrypted file " + s.Path
+}
+
+// Get is part of CredentialStore implementation.
+func (s *FileStore) Get(host string) (string, error) {
+	tokens, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	return tokens[host], nil
+}
+
+// Store is part of CredentialStore implementation.
+func (s *FileStore) Store(host, token string) error {
+	unlock, err := lockFile(s.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	tokens[host] = token
+	return s.save(tokens)
+}
+
+// Erase is part of CredentialStore implementation.
+func (s *FileStore) Erase(host string) (bool, error) {
+	unlock, err := lockFile(s.Path)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	tokens, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := tokens[host]; !ok {
+		return false, nil
+	}
+	delete(tokens, host)
+	return true, s.save(tokens)
+}
+
+func (s *FileStore) load() (map[string]string, error) {
+	tokens := map[string]string{}
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(data, fileMagic) || len(data) < len(fileMagic)+saltSize {
+		return nil, fmt.Errorf("Invalid credentials file %s", s.Path)
+	}
+	data = data[len(fileMagic):]
+
+	aead, err := s.cipher(data[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[saltSize:]
+
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("Invalid credentials file %s", s.Path)
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decrypt credentials file %s: wrong passphrase?", s.Path)
+	}
+
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, fmt.Errorf("Invalid credentials file %s: %s", s.Path, err)
+	}
+	return tokens, nil
+}
+
+func (s *FileStore) save(tokens map[string]string) error {
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	aead, err := s.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data := append(append(append([]byte{}, fileMagic...), salt...), nonce...)
+	data = aead.Seal(data, nonce, plain, nil)
+
+	return writeFileAtomic(s.Path, data)
+}
+
+func (s *FileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	if s.Passphrase == "" {
+		return nil, fmt.Errorf("%s is required by the encrypted file credential store", PassphraseEnv)
+	}
+
+	key, err := scrypt.Key([]byte(s.Passphrase), salt, 1<<15, 8, 1, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,104 @@
+package tokenstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// helperPrefix prefixes the names of the credential helper executables
+const helperPrefix = "sqsc-credential-"
+
+// helperNotFound is the message of the helpers when there are no
+// credentials for the host, as with docker credential helpers
+const helperNotFound = "credentials not found"
+
+// helperCredentials is the payload exchanged with the credential helpers
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// HelperStore delegates the storage of the tokens to an external credential
+// helper, the sqsc-credential-<name> executable, following the protocol of
+// docker credential helpers:
+// - get reads the host on stdin and writes {"ServerURL", "Username",
+// "Secret"} as JSON on stdout
+// - store reads {"ServerURL", "Username", "Secret"} as JSON on stdin
+// - erase reads the host on stdin
+// A helper fails with "credentials not found" when it has no token for the
+// host.
+type HelperStore struct {
+	Program string
+}
+
+// NewHelperStore returns the store of the credential helper of the given
+// name.
+func NewHelperStore(name string) *HelperStore {
+	return &HelperStore{Program: helperPrefix + name}
+}
+
+func (s *HelperStore) String() string {
+	return "credential helper " + s.Program
+}
+
+// Get is part of CredentialStore implementation.
+func (s *HelperStore) Get(host string) (string, error) {
+	out, err := s.run("get", host)
+	if err == errHelperNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	var credentials helperCredentials
+	if err := json.Unmarshal(out, &credentials); err != nil {
+		return "", fmt.Errorf("Invalid answer of %s: %s", s.Program, err)
+	}
+	return credentials.Secret, nil
+}
+
+// Store is part of CredentialStore implementation.
+func (s *HelperStore) Store(host, token string) error {
+	payload, err := json.Marshal(helperCredentials{ServerURL: host, Username: "sqsc", Secret: token})
+	if err != nil {
+		return err
+	}
+	_, err = s.run("store", string(payload))
+	return err
+}
+
+// Erase is part of CredentialStore implementation.
+func (s *HelperStore) Erase(host string) (bool, error) {
+	_, err := s.run("erase", host)
+	if err == errHelperNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+var errHelperNotFound = errors.New(helperNotFound)
+
+func (s *HelperStore) run(action, input string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	c := exec.Command(s.Program, action)
+	c.Stdin = strings.NewReader(input + "\n")
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	if err := c.Run(); err != nil {
+		message := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if strings.Contains(message, helperNotFound) {
+			return nil, errHelperNotFound
+		}
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("Credential helper %s %s failed: %s", s.Program, action, message)
+	}
+	return stdout.Bytes(), nil
+}
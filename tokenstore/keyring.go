@@ -0,0 +1,24 @@
+package tokenstore
+
+import "runtime"
+
+// keyringHelper returns the docker credential helper of the keyring of the
+// OS: the macOS keychain, the Windows credential manager or the Secret
+// Service of the desktop (GNOME Keyring, KWallet) elsewhere
+func keyringHelper() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "docker-credential-osxkeychain"
+	case "windows":
+		return "docker-credential-wincred"
+	default:
+		return "docker-credential-secretservice"
+	}
+}
+
+// NewKeyringStore returns the store of the keyring of the OS. The keyring is
+// reached through its docker credential helper, which has to be installed,
+// so that no native library is linked in the CLI.
+func NewKeyringStore() *HelperStore {
+	return &HelperStore{Program: keyringHelper()}
+}
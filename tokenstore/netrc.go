@@ -0,0 +1,159 @@
+package tokenstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/squarescale/go-netrc/netrc"
+)
+
+// Warnings receives the warnings about the netrc files, on stderr so that
+// they do not mix with the output of the commands
+var Warnings io.Writer = os.Stderr
+
+var (
+	warnedMutex sync.Mutex
+	// warned holds the netrc files already reported as readable by others
+	warned = map[string]bool{}
+)
+
+func netrcFile() string {
+	netrc := os.Getenv("SQSC_NETRC")
+	if netrc == "" {
+		netrc = os.Getenv("HOME") + "/.netrc"
+	}
+	return netrc
+}
+
+// NetrcStore stores the tokens as passwords of the machines of a netrc file.
+// The file is locked while updated, and written atomically with 0600
+// permissions.
+type NetrcStore struct {
+	Path string
+}
+
+// NewNetrcStore returns the store of the netrc file at path.
+func NewNetrcStore(path string) *NetrcStore {
+	return &NetrcStore{Path: path}
+}
+
+func (s *NetrcStore) String() string {
+	return "netrc file " + s.Path
+}
+
+// Get is part of CredentialStore implementation.
+func (s *NetrcStore) Get(host string) (string, error) {
+	n, err := s.parse()
+	if err != nil {
+		return "", err
+	}
+
+	if m := findMachine(n, host); m != nil {
+		return m.Password, nil
+	}
+	return "", nil
+}
+
+// Store is part of CredentialStore implementation.
+func (s *NetrcStore) Store(host, token string) error {
+	unlock, err := lockFile(s.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	n, err := s.parse()
+	if err != nil {
+		return err
+	}
+
+	if m := findMachine(n, host); m != nil {
+		m.UpdatePassword(token)
+	} else {
+		n.NewMachine(host, "", token, "")
+	}
+	return s.save(n)
+}
+
+// Erase is part of CredentialStore implementation.
+func (s *NetrcStore) Erase(host string) (bool, error) {
+	unlock, err := lockFile(s.Path)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	n, err := s.parse()
+	if err != nil {
+		return false, err
+	}
+
+	if findMachine(n, host) == nil {
+		return false, nil
+	}
+
+	n.RemoveMachine(host)
+	return true, s.save(n)
+}
+
+// findMachine returns the entry of the host, ignoring the default one which
+// is meant for other hosts.
+func findMachine(n *netrc.Netrc, host string) *netrc.Machine {
+	for _, m := range n.FindMachines(host) {
+		if m.Name == host {
+			return m
+		}
+	}
+	return nil
+}
+
+// parse reads the netrc file, empty when missing, and warns when others can
+// read it.
+func (s *NetrcStore) parse() (*netrc.Netrc, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return netrc.Parse(bytes.NewReader(nil))
+	} else if err != nil {
+		return nil, err
+	}
+
+	s.checkPermissions()
+
+	n, err := netrc.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid netrc file %s: %s", s.Path, err)
+	}
+	return n, nil
+}
+
+func (s *NetrcStore) checkPermissions() {
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	info, err := os.Stat(s.Path)
+	if err != nil || info.Mode().Perm()&0077 == 0 {
+		return
+	}
+
+	warnedMutex.Lock()
+	defer warnedMutex.Unlock()
+	if !warned[s.Path] {
+		warned[s.Path] = true
+		fmt.Fprintf(Warnings, "Warning: %s is readable by other users (mode %s), run: chmod 600 %s\n", s.Path, info.Mode().Perm(), s.Path)
+	}
+}
+
+// save writes the netrc file atomically with 0600 permissions.
+func (s *NetrcStore) save(n *netrc.Netrc) error {
+	text, err := n.MarshalText()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.Path, text)
+}
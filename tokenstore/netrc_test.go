@@ -0,0 +1,196 @@
+package tokenstore_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/squarescale/squarescale-cli/tokenstore"
+)
+
+func TestNetrcStore(t *testing.T) {
+	t.Run("Test missing file", testNetrcMissingFile)
+	t.Run("Test entries with a login", testNetrcEntriesWithLogin)
+	t.Run("Test other entries are kept", testNetrcOtherEntries)
+	t.Run("Test permissions", testNetrcPermissions)
+	t.Run("Test concurrent updates", testNetrcConcurrentUpdates)
+	t.Run("Test stale lock", testNetrcStaleLock)
+	t.Run("Test symbolic link", testNetrcSymlink)
+}
+
+func testNetrcMissingFile(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "netrc")
+
+	// when
+	token, err := tokenstore.NewNetrcStore(path).Get("https://a")
+
+	// then
+	if err != nil || token != "" {
+		t.Errorf("Expect no token, got `%s`, %v", token, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expect the file not to be created by Get")
+	}
+}
+
+func testNetrcEntriesWithLogin(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "netrc")
+	os.WriteFile(path, []byte("machine https://a login me password t0k3n\n"), 0600)
+	store := tokenstore.NewNetrcStore(path)
+
+	// when
+	token, err := store.Get("https://a")
+
+	// then
+	if err != nil || token != "t0k3n" {
+		t.Errorf("Expect the password of the entry, got `%s`, %v", token, err)
+	}
+
+	// when
+	err = store.Store("https://a", "n3w")
+
+	// then
+	data, _ := os.ReadFile(path)
+	if err != nil || strings.Count(string(data), "https://a") != 1 || !strings.Contains(string(data), "n3w") {
+		t.Errorf("Expect the entry to be updated, got `%s`, %v", data, err)
+	}
+}
+
+func testNetrcOtherEntries(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "netrc")
+	os.WriteFile(path, []byte("machine github.com login me password gh\ndefault login anonymous password guest\n"), 0600)
+	store := tokenstore.NewNetrcStore(path)
+
+	// when
+	token, err := store.Get("https://a")
+
+	// then
+	if err != nil || token != "" {
+		t.Errorf("Expect the default entry to be ignored, got `%s`, %v", token, err)
+	}
+
+	// when
+	store.Store("https://a", "t0k3n")
+	erased, err := store.Erase("https://a")
+
+	// then
+	if err != nil || !erased {
+		t.Errorf("Expect the token to be erased, got %v, %v", erased, err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, expected := range []string{"machine github.com login me password gh", "default login anonymous password guest"} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expect `%s` to be kept, got `%s`", expected, data)
+		}
+	}
+}
+
+func testNetrcPermissions(t *testing.T) {
+	// given
+	var warnings bytes.Buffer
+	tokenstore.Warnings = &warnings
+	defer func() { tokenstore.Warnings = os.Stderr }()
+
+	path := filepath.Join(t.TempDir(), "netrc")
+	os.WriteFile(path, []byte("machine github.com login me password gh\n"), 0644)
+	os.Chmod(path, 0644)
+	store := tokenstore.NewNetrcStore(path)
+
+	// when
+	store.Get("https://a")
+	store.Get("https://a")
+
+	// then
+	if strings.Count(warnings.String(), "readable by other users") != 1 {
+		t.Errorf("Expect a single warning, got `%s`", warnings.String())
+	}
+
+	// when
+	err := store.Store("https://a", "t0k3n")
+
+	// then
+	info, _ := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expect the file to be written with 0600, got %s, %v", info.Mode(), err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expect no temporary or lock file left, got %v", entries)
+	}
+}
+
+func testNetrcConcurrentUpdates(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "netrc")
+	var wg sync.WaitGroup
+
+	// when
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := tokenstore.NewNetrcStore(path).Store(fmt.Sprintf("https://host%d", i), "t0k3n"); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// then
+	store := tokenstore.NewNetrcStore(path)
+	for i := 0; i < 10; i++ {
+		if token, err := store.Get(fmt.Sprintf("https://host%d", i)); err != nil || token != "t0k3n" {
+			t.Errorf("Expect the token of host%d to be kept, got `%s`, %v", i, token, err)
+		}
+	}
+}
+
+func testNetrcStaleLock(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "netrc")
+	os.WriteFile(path+".lock", nil, 0600)
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(path+".lock", old, old)
+
+	// when
+	err := tokenstore.NewNetrcStore(path).Store("https://a", "t0k3n")
+
+	// then
+	if err != nil {
+		t.Errorf("Expect the stale lock to be removed, got %v", err)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Error("Expect the lock to be released")
+	}
+}
+
+func testNetrcSymlink(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles-netrc")
+	os.WriteFile(target, nil, 0600)
+	link := filepath.Join(dir, "netrc")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skip("Symbolic links not supported:", err)
+	}
+
+	// when
+	err := tokenstore.NewNetrcStore(link).Store("https://a", "t0k3n")
+
+	// then
+	if info, _ := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expect the link to be kept, got %v", err)
+	}
+	if data, _ := os.ReadFile(target); !strings.Contains(string(data), "t0k3n") {
+		t.Errorf("Expect the target to be written, got `%s`", data)
+	}
+}
@@ -1,85 +1,132 @@
 package tokenstore
 
 import (
-	"bytes"
-	"io/ioutil"
+	"fmt"
 	"os"
+	"os/exec"
+	"regexp"
 	"strings"
 
-	"github.com/squarescale/go-netrc/netrc"
+	"github.com/squarescale/squarescale-cli/config"
 )
 
-func netrcFile() string {
-	netrc := os.Getenv("SQSC_NETRC")
-	if netrc == "" {
-		netrc = os.Getenv("HOME") + "/.netrc"
+// StoreEnv is the environment variable selecting the credential store,
+// instead of the one of the configuration file
+const StoreEnv = "SQSC_CREDENTIAL_STORE"
+
+// Names of the built-in credential stores, any other name being the one of
+// a credential helper
+const (
+	StoreNetrc   = "netrc"
+	StoreFile    = "file"
+	StoreKeyring = "keyring"
+)
+
+// helperName matches the valid names of credential helpers
+var helperName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// CredentialStore persists the SquareScale tokens by host.
+type CredentialStore interface {
+	// Get returns the token of the host, empty when there is none
+	Get(host string) (string, error)
+	// Store saves the token of the host
+	Store(host, token string) error
+	// Erase removes the token of the host, telling whether there was one
+	Erase(host string) (bool, error)
+	// String describes the store, as in "netrc file ~/.netrc"
+	String() string
+}
+
+// NewStore returns the credential store of the given name: the netrc file
+// by default, the encrypted file, the keyring of the OS, or the credential
+// helper of that name. An error is returned when the helper of the keyring
+// or of the given name can not be found in the PATH.
+func NewStore(name string) (CredentialStore, error) {
+	switch name {
+	case "", StoreNetrc:
+		return NewNetrcStore(netrcFile()), nil
+	case StoreFile:
+		return NewFileStore(credentialsFile(), os.Getenv(PassphraseEnv)), nil
+	case StoreKeyring:
+		store := NewKeyringStore()
+		if _, err := exec.LookPath(store.Program); err != nil {
+			return nil, fmt.Errorf("The keyring credential store needs %s, which is not in the PATH", store.Program)
+		}
+		return store, nil
+	}
+
+	if !helperName.MatchString(name) {
+		return nil, fmt.Errorf("Invalid credential store '%s'", name)
 	}
-	return netrc
+	store := NewHelperStore(name)
+	if _, err := exec.LookPath(store.Program); err != nil {
+		return nil, fmt.Errorf("Unknown credential store '%s': not %s, %s or %s, and no credential helper %s in the PATH", name, StoreNetrc, StoreFile, StoreKeyring, store.Program)
+	}
+	return store, nil
 }
 
-func initNetrcFileIfNotExist() {
-	var _, err = os.Stat(netrcFile())
-	if os.IsNotExist(err) {
-		var file, _ = os.Create(netrcFile())
-		defer file.Close()
+// CurrentStore returns the credential store selected by SQSC_CREDENTIAL_STORE
+// or, by default, by the configuration file.
+func CurrentStore() (CredentialStore, error) {
+	name := os.Getenv(StoreEnv)
+	if name == "" {
+		c, err := config.Load()
+		if err != nil {
+			return nil, err
+		}
+		name = c.CredentialStore
 	}
+	return NewStore(name)
 }
 
 // GetToken retrieves the SquareScale token in the token store.
 func GetToken(host string) (string, error) {
-	var apiKey string
-	apiKey = os.Getenv("SQSC_TOKEN")
-	if apiKey != "" {
-		return apiKey, nil
+	token, _, err := LookupToken(host)
+	return token, err
+}
+
+// LookupToken retrieves the SquareScale token along with a description of
+// where it comes from, empty when there is no token. The first source giving
+// a token is used:
+// - SQSC_TOKEN
+// - the file given by SQSC_TOKEN_FILE
+// - the output of the command given by SQSC_TOKEN_COMMAND, run once
+// - the token store
+func LookupToken(host string) (string, string, error) {
+	apiKey, source, err := envToken()
+	if err != nil || apiKey != "" {
+		return apiKey, source, err
 	}
 
-	host = normalizeHost(host)
-	initNetrcFileIfNotExist()
-	n, err := netrc.ParseFile(netrcFile())
+	store, err := CurrentStore()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	for _, m := range n.FindMachines(host) {
-		if m.Login != "" || m.Account != "" {
-			continue
-		}
-		return m.Password, nil
+	apiKey, err = store.Get(normalizeHost(host))
+	if err != nil || apiKey == "" {
+		return "", "", err
 	}
-
-	return "", nil
+	return apiKey, store.String(), nil
 }
 
 // SaveToken persists the SquareScale token for the given host in the token store.
 func SaveToken(host, token string) error {
-	host = normalizeHost(host)
-	n, err := netrc.ParseFile(netrcFile())
-	if err != nil && os.IsNotExist(err) {
-		n, err = netrc.Parse(bytes.NewReader(nil))
-	}
+	store, err := CurrentStore()
 	if err != nil {
 		return err
 	}
-
-	for _, m := range n.FindMachines(host) {
-		if m.Login != "" || m.Account != "" {
-			continue
-		}
-		m.UpdatePassword(token)
-		return saveNetrc(n)
-	}
-
-	n.NewMachine(host, "", token, "")
-	return saveNetrc(n)
+	return store.Store(normalizeHost(host), token)
 }
 
-func saveNetrc(n *netrc.Netrc) error {
-	text, err := n.MarshalText()
+// RemoveToken removes the SquareScale token for the given host from the token
+// store, telling whether there was one.
+func RemoveToken(host string) (bool, error) {
+	store, err := CurrentStore()
 	if err != nil {
-		return err
+		return false, err
 	}
-
-	return ioutil.WriteFile(netrcFile(), text, 0600)
+	return store.Erase(normalizeHost(host))
 }
 
 func normalizeHost(host string) string {
@@ -0,0 +1,357 @@
+package tokenstore_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/tokenstore"
+)
+
+// fakeHelper is a credential helper storing the tokens as files named after
+// the hosts in $FAKE_HELPER_DIR
+const fakeHelper = `#!/bin/sh
+read -r input
+case "$1" in
+get)
+	file="$FAKE_HELPER_DIR/$(echo "$input" | tr -c 'a-z0-9\n' _)"
+	[ -f "$file" ] || { echo "credentials not found in fake store"; exit 1; }
+	printf '{"ServerURL":"%s","Username":"sqsc","Secret":"%s"}' "$input" "$(cat "$file")"
+	;;
+store)
+	host=$(echo "$input" | sed 's/.*"ServerURL":"\([^"]*\)".*/\1/')
+	secret=$(echo "$input" | sed 's/.*"Secret":"\([^"]*\)".*/\1/')
+	printf '%s' "$secret" > "$FAKE_HELPER_DIR/$(echo "$host" | tr -c 'a-z0-9\n' _)"
+	;;
+erase)
+	file="$FAKE_HELPER_DIR/$(echo "$input" | tr -c 'a-z0-9\n' _)"
+	[ -f "$file" ] || { echo "credentials not found in fake store" >&2; exit 1; }
+	rm "$file"
+	;;
+*)
+	echo "unknown action $1" >&2
+	exit 2
+esac
+`
+
+func TestTokenStore(t *testing.T) {
+	t.Run("Test netrc store", func(t *testing.T) {
+		testStore(t, tokenstore.NewNetrcStore(filepath.Join(t.TempDir(), "netrc")))
+	})
+	t.Run("Test encrypted file store", func(t *testing.T) {
+		testStore(t, tokenstore.NewFileStore(filepath.Join(t.TempDir(), "credentials"), "passphrase"))
+	})
+	t.Run("Test helper store", func(t *testing.T) {
+		installFakeHelper(t)
+		testStore(t, tokenstore.NewHelperStore("fake"))
+	})
+	t.Run("Test encrypted file store with wrong passphrase", testFileStoreWrongPassphrase)
+	t.Run("Test encrypted file store concurrent updates", testFileStoreConcurrentUpdates)
+	t.Run("Test helper store failure", testHelperStoreFailure)
+	t.Run("Test keyring store", testKeyringStore)
+	t.Run("Test store selection", testStoreSelection)
+	t.Run("Test unknown store", testUnknownStore)
+	t.Run("Test SQSC_TOKEN", testTokenFromEnv)
+	t.Run("Test SQSC_TOKEN_FILE", testTokenFromFile)
+	t.Run("Test SQSC_TOKEN_COMMAND", testTokenFromCommand)
+	t.Run("Test token sources precedence", testTokenSourcesPrecedence)
+}
+
+func installFakeHelper(t *testing.T, programs ...string) {
+	dir := t.TempDir()
+	for _, program := range append(programs, "sqsc-credential-fake") {
+		if err := os.WriteFile(filepath.Join(dir, program), []byte(fakeHelper), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_HELPER_DIR", t.TempDir())
+}
+
+func testStore(t *testing.T, store tokenstore.CredentialStore) {
+	host := "https://www.squarescale.io"
+
+	// given no token
+	if token, err := store.Get(host); err != nil || token != "" {
+		t.Fatalf("Expect no token, got `%s`, %v", token, err)
+	}
+
+	// when
+	if err := store.Store(host, "t0k3n"); err != nil {
+		t.Fatalf("Expect no error on Store, got %s", err)
+	}
+	if err := store.Store("https://other", "other"); err != nil {
+		t.Fatalf("Expect no error on Store, got %s", err)
+	}
+
+	// then
+	if token, err := store.Get(host); err != nil || token != "t0k3n" {
+		t.Errorf("Expect stored token, got `%s`, %v", token, err)
+	}
+
+	// when
+	if err := store.Store(host, "n3w"); err != nil {
+		t.Fatalf("Expect no error on Store, got %s", err)
+	}
+
+	// then
+	if token, err := store.Get(host); err != nil || token != "n3w" {
+		t.Errorf("Expect updated token, got `%s`, %v", token, err)
+	}
+
+	// when
+	erased, err := store.Erase(host)
+
+	// then
+	if err != nil || !erased {
+		t.Errorf("Expect the token to be erased, got %v, %v", erased, err)
+	}
+	if token, err := store.Get(host); err != nil || token != "" {
+		t.Errorf("Expect no token once erased, got `%s`, %v", token, err)
+	}
+	if erased, err := store.Erase(host); err != nil || erased {
+		t.Errorf("Expect nothing to erase, got %v, %v", erased, err)
+	}
+	if token, err := store.Get("https://other"); err != nil || token != "other" {
+		t.Errorf("Expect other tokens to be kept, got `%s`, %v", token, err)
+	}
+}
+
+func testFileStoreWrongPassphrase(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := tokenstore.NewFileStore(path, "right").Store("https://a", "t0k3n"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "t0k3n") {
+		t.Error("Expect the token to be encrypted")
+	}
+
+	// when
+	_, err = tokenstore.NewFileStore(path, "wrong").Get("https://a")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Expect a decryption error, got %v", err)
+	}
+
+	if _, err := tokenstore.NewFileStore(path, "").Get("https://a"); err == nil {
+		t.Error("Expect an error without passphrase")
+	}
+}
+
+func testFileStoreConcurrentUpdates(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "credentials")
+	var wg sync.WaitGroup
+
+	// when
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := tokenstore.NewFileStore(path, "passphrase").Store(fmt.Sprintf("https://host%d", i), "t0k3n"); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// then
+	store := tokenstore.NewFileStore(path, "passphrase")
+	for i := 0; i < 5; i++ {
+		if token, err := store.Get(fmt.Sprintf("https://host%d", i)); err != nil || token != "t0k3n" {
+			t.Errorf("Expect the token of host%d to be kept, got `%s`, %v", i, token, err)
+		}
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expect no temporary or lock file left, got %v", entries)
+	}
+}
+
+func testHelperStoreFailure(t *testing.T) {
+	// given
+	t.Setenv("PATH", t.TempDir())
+
+	// when
+	_, err := tokenstore.NewHelperStore("missing").Get("https://a")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "sqsc-credential-missing") {
+		t.Errorf("Expect an error naming the helper, got %v", err)
+	}
+}
+
+func testKeyringStore(t *testing.T) {
+	// given
+	installFakeHelper(t, tokenstore.NewKeyringStore().Program)
+
+	// when
+	store, err := tokenstore.NewStore(tokenstore.StoreKeyring)
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	testStore(t, store)
+}
+
+func testUnknownStore(t *testing.T) {
+	// given
+	t.Setenv("PATH", t.TempDir())
+
+	for name, expected := range map[string]string{
+		"typo":                  "Unknown credential store 'typo': not netrc, file or keyring, and no credential helper sqsc-credential-typo in the PATH",
+		"../bin/sh":             "Invalid credential store '../bin/sh'",
+		tokenstore.StoreKeyring: "The keyring credential store needs " + tokenstore.NewKeyringStore().Program + ", which is not in the PATH",
+	} {
+		// when
+		_, err := tokenstore.NewStore(name)
+
+		// then
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expect error `%s`, got %v", expected, err)
+		}
+	}
+}
+
+func testStoreSelection(t *testing.T) {
+	// given
+	installFakeHelper(t)
+	t.Setenv("SQSC_TOKEN", "")
+	t.Setenv(tokenstore.TokenFileEnv, "")
+	t.Setenv(tokenstore.TokenCommandEnv, "")
+	t.Setenv(tokenstore.StoreEnv, "fake")
+
+	// when
+	if err := tokenstore.SaveToken("https://a/", "t0k3n"); err != nil {
+		t.Fatalf("Expect no error, got %s", err)
+	}
+	token, source, err := tokenstore.LookupToken("https://a")
+
+	// then
+	if err != nil || token != "t0k3n" || source != "credential helper sqsc-credential-fake" {
+		t.Errorf("Expect token from the helper, got `%s` from `%s`, %v", token, source, err)
+	}
+
+	// when
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(config, []byte("credential-store: file\n"), 0600)
+	t.Setenv("SQSC_CONFIG", config)
+	t.Setenv(tokenstore.StoreEnv, "")
+	store, err := tokenstore.CurrentStore()
+
+	// then
+	if err != nil || !strings.HasPrefix(store.String(), "encrypted file ") {
+		t.Errorf("Expect the store of the configuration file, got %v, %v", store, err)
+	}
+}
+
+func testTokenFromEnv(t *testing.T) {
+	// given
+	t.Setenv("SQSC_TOKEN", "from-env")
+	t.Setenv("SQSC_NETRC", filepath.Join(t.TempDir(), "netrc"))
+
+	// when
+	token, source, err := tokenstore.LookupToken("https://a")
+
+	// then
+	if err != nil || token != "from-env" || source != "environment variable SQSC_TOKEN" {
+		t.Errorf("Expect token from SQSC_TOKEN, got `%s` from `%s`, %v", token, source, err)
+	}
+}
+
+func testTokenFromFile(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(path, []byte("  from-file\n"), 0600)
+	t.Setenv("SQSC_TOKEN", "")
+	t.Setenv(tokenstore.TokenFileEnv, path)
+
+	// when
+	token, source, err := tokenstore.LookupToken("https://a")
+
+	// then
+	if err != nil || token != "from-file" || source != "file "+path+" (SQSC_TOKEN_FILE)" {
+		t.Errorf("Expect token from SQSC_TOKEN_FILE, got `%s` from `%s`, %v", token, source, err)
+	}
+
+	// when
+	t.Setenv(tokenstore.TokenFileEnv, path+".missing")
+	_, _, err = tokenstore.LookupToken("https://a")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), tokenstore.TokenFileEnv) {
+		t.Errorf("Expect an error on missing token file, got %v", err)
+	}
+}
+
+func testTokenFromCommand(t *testing.T) {
+	// given
+	counter := filepath.Join(t.TempDir(), "counter")
+	t.Setenv("SQSC_TOKEN", "")
+	t.Setenv(tokenstore.TokenFileEnv, "")
+	t.Setenv(tokenstore.TokenCommandEnv, "echo run >> "+counter+"; echo ' from-command '")
+
+	// when
+	for i := 0; i < 2; i++ {
+		token, source, err := tokenstore.LookupToken("https://a")
+
+		// then
+		if err != nil || token != "from-command" || source != "command of SQSC_TOKEN_COMMAND" {
+			t.Errorf("Expect token from SQSC_TOKEN_COMMAND, got `%s` from `%s`, %v", token, source, err)
+		}
+	}
+
+	// then
+	if data, _ := os.ReadFile(counter); string(data) != "run\n" {
+		t.Errorf("Expect the command to be run once, got `%s`", data)
+	}
+
+	// when
+	t.Setenv(tokenstore.TokenCommandEnv, "echo denied >&2; exit 1")
+	_, _, err := tokenstore.LookupToken("https://a")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Expect the error of the command, got %v", err)
+	}
+}
+
+func testTokenSourcesPrecedence(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(path, []byte("from-file"), 0600)
+	t.Setenv(tokenstore.TokenFileEnv, path)
+	t.Setenv(tokenstore.TokenCommandEnv, "echo from-command-precedence")
+
+	for _, c := range []struct {
+		env, expected string
+		external      bool
+	}{
+		{"from-env", "from-env", false},
+		{"", "from-file", true},
+	} {
+		// when
+		t.Setenv("SQSC_TOKEN", c.env)
+		token, _, err := tokenstore.LookupToken("https://a")
+
+		// then
+		if err != nil || token != c.expected {
+			t.Errorf("Expect token `%s`, got `%s`, %v", c.expected, token, err)
+		}
+		if tokenstore.ExternalToken() != c.external {
+			t.Errorf("Expect ExternalToken() to be %v with token `%s`", c.external, c.expected)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the read commands
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputCSV   = "csv"
+
+	// OutputTemplate prefixes a Go template rendered for each item, as in
+	// template={{.Name}}
+	OutputTemplate = "template="
+)
+
+// OutputFormats lists the supported output formats
+var OutputFormats = []string{OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputTemplate + "<go template>"}
+
+// ValidOutputFormat tells whether format is a supported output format
+func ValidOutputFormat(format string) bool {
+	if strings.HasPrefix(format, OutputTemplate) {
+		return len(format) > len(OutputTemplate)
+	}
+
+	for _, f := range OutputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatOutput renders data in a machine-readable output format. The schema
+// is the JSON one of the data for every format: YAML uses the same keys and
+// CSV has one column per top-level key, nested values being JSON encoded.
+// Templates are executed on the data itself and use its Go field names.
+func FormatOutput(format string, data interface{}) (string, error) {
+	if strings.HasPrefix(format, OutputTemplate) {
+		return formatTemplate(strings.TrimPrefix(format, OutputTemplate), data)
+	}
+
+	switch format {
+	case OutputJSON:
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(buf.String(), "\n"), nil
+	case OutputYAML:
+		value, err := normalize(data)
+		if err != nil {
+			return "", err
+		}
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(string(out), "\n"), nil
+	case OutputCSV:
+		value, err := normalize(data)
+		if err != nil {
+			return "", err
+		}
+		return formatCSV(value)
+	default:
+		return "", fmt.Errorf("Unknown output format: %s. Correct values are %s", format, strings.Join(OutputFormats, ", "))
+	}
+}
+
+// formatTemplate executes the template with each item of data when it is a
+// list, one line per item, or with data itself otherwise.
+func formatTemplate(text string, data interface{}) (string, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("Invalid output template: %s", err)
+	}
+
+	var items []interface{}
+	value := reflect.ValueOf(data)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, value.Index(i).Interface())
+		}
+	} else {
+		items = append(items, data)
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return "", err
+		}
+		lines = append(lines, buf.String())
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// normalize converts data to the generic values of its JSON representation,
+// keeping integers as such.
+func normalize(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return convertNumbers(value), nil
+}
+
+func convertNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = convertNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = convertNumbers(e)
+		}
+	}
+	return value
+}
+
+// formatCSV renders a list of objects, or a single object, as CSV with a
+// header line made of the sorted keys of all the objects.
+func formatCSV(value interface{}) (string, error) {
+	var rows []map[string]interface{}
+	switch v := value.(type) {
+	case nil:
+	case []interface{}:
+		for _, e := range v {
+			row, ok := e.(map[string]interface{})
+			if !ok {
+				row = map[string]interface{}{"value": e}
+			}
+			rows = append(rows, row)
+		}
+	case map[string]interface{}:
+		rows = append(rows, v)
+	default:
+		rows = append(rows, map[string]interface{}{"value": v})
+	}
+
+	keySet := map[string]bool{}
+	for _, row := range rows {
+		for k := range row {
+			keySet[k] = true
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(keys); err != nil {
+		return "", err
+	}
+	for _, row := range rows {
+		record := make([]string, len(keys))
+		for i, k := range keys {
+			cell, err := csvCell(row[k])
+			if err != nil {
+				return "", err
+			}
+			record[i] = cell
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func csvCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		out, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+}
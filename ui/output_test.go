@@ -0,0 +1,95 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/ui"
+)
+
+type item struct {
+	Name  string            `json:"name"`
+	Size  int               `json:"size"`
+	Ready bool              `json:"ready"`
+	Tags  map[string]string `json:"tags"`
+}
+
+var items = []item{
+	{Name: "web", Size: 1000000, Ready: true, Tags: map[string]string{"env": "prod"}},
+	{Name: "worker, batch", Size: 2},
+}
+
+func TestFormatOutput(t *testing.T) {
+	t.Run("Test json output", JSONOutput)
+	t.Run("Test yaml output", YAMLOutput)
+	t.Run("Test csv output", CSVOutput)
+	t.Run("Test template output", TemplateOutput)
+	t.Run("Test unknown output", UnknownOutput)
+}
+
+func JSONOutput(t *testing.T) {
+	// when
+	out, err := ui.FormatOutput(ui.OutputJSON, items[1:])
+
+	// then
+	expected := `[
+  {
+    "name": "worker, batch",
+    "size": 2,
+    "ready": false,
+    "tags": null
+  }
+]`
+	if err != nil || out != expected {
+		t.Fatalf("Expect `%s`, got `%s` (%v)", expected, out, err)
+	}
+}
+
+func YAMLOutput(t *testing.T) {
+	// when
+	out, err := ui.FormatOutput(ui.OutputYAML, items[:1])
+
+	// then
+	expected := `- name: web
+  ready: true
+  size: 1000000
+  tags:
+    env: prod`
+	if err != nil || out != expected {
+		t.Fatalf("Expect `%s`, got `%s` (%v)", expected, out, err)
+	}
+}
+
+func CSVOutput(t *testing.T) {
+	// when
+	out, err := ui.FormatOutput(ui.OutputCSV, items)
+
+	// then
+	expected := `name,ready,size,tags
+web,true,1000000,"{""env"":""prod""}"
+"worker, batch",false,2,`
+	if err != nil || out != expected {
+		t.Fatalf("Expect `%s`, got `%s` (%v)", expected, out, err)
+	}
+}
+
+func TemplateOutput(t *testing.T) {
+	// when
+	out, err := ui.FormatOutput("template={{.Name}} {{.Size}}", items)
+
+	// then
+	expected := "web 1000000\nworker, batch 2"
+	if err != nil || out != expected {
+		t.Fatalf("Expect `%s`, got `%s` (%v)", expected, out, err)
+	}
+}
+
+func UnknownOutput(t *testing.T) {
+	// when
+	_, err := ui.FormatOutput("xml", items)
+
+	// then
+	expected := "Unknown output format: xml. Correct values are table, json, yaml, csv, template=<go template>"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expect error `%s`, got `%v`", expected, err)
+	}
+}
@@ -1,6 +1,14 @@
 package ui
 
-import "github.com/olekukonko/tablewriter"
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
 
 func FormatTable(table *tablewriter.Table) {
 	table.SetAutoWrapText(false)
@@ -15,3 +23,164 @@ func FormatTable(table *tablewriter.Table) {
 	table.SetTablePadding("\t")
 	table.SetNoWhiteSpace(true)
 }
+
+// Column describes a column of a Table. Its Name, derived from the header,
+// identifies it on the command line.
+type Column struct {
+	Name   string
+	Header string
+}
+
+// Table is a list of rows of text rendered with FormatTable, whose columns
+// can be selected and sorted.
+type Table struct {
+	columns []Column
+	rows    [][]string
+}
+
+// NewTable returns an empty table with the given column headers
+func NewTable(headers ...string) *Table {
+	columns := make([]Column, 0, len(headers))
+	for _, header := range headers {
+		columns = append(columns, Column{Name: columnName(header), Header: header})
+	}
+	return &Table{columns: columns}
+}
+
+func columnName(header string) string {
+	return strings.Join(strings.Fields(strings.ToLower(header)), "-")
+}
+
+// Columns returns the columns of the table
+func (t *Table) Columns() []Column {
+	return t.columns
+}
+
+// Append adds a row to the table, one cell per column
+func (t *Table) Append(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// Len returns the number of rows of the table
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+func (t *Table) columnIndex(name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, c := range t.columns {
+		if c.Name == name {
+			return i, nil
+		}
+	}
+
+	names := make([]string, 0, len(t.columns))
+	for _, c := range t.columns {
+		names = append(names, c.Name)
+	}
+	return -1, fmt.Errorf("Unknown column: %s. Correct values are %s", name, strings.Join(names, ", "))
+}
+
+// SelectColumns keeps only the named columns, in the given order
+func (t *Table) SelectColumns(names []string) error {
+	indexes := make([]int, 0, len(names))
+	for _, name := range names {
+		i, err := t.columnIndex(name)
+		if err != nil {
+			return err
+		}
+		indexes = append(indexes, i)
+	}
+
+	columns := make([]Column, 0, len(indexes))
+	for _, i := range indexes {
+		columns = append(columns, t.columns[i])
+	}
+
+	rows := make([][]string, 0, len(t.rows))
+	for _, row := range t.rows {
+		cells := make([]string, 0, len(indexes))
+		for _, i := range indexes {
+			cells = append(cells, cell(row, i))
+		}
+		rows = append(rows, cells)
+	}
+
+	t.columns = columns
+	t.rows = rows
+	return nil
+}
+
+// SortBy sorts the rows on the named column, numerically when both values
+// are numbers
+func (t *Table) SortBy(name string) error {
+	i, err := t.columnIndex(name)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(t.rows, func(a, b int) bool {
+		left, right := cell(t.rows[a], i), cell(t.rows[b], i)
+		l, lerr := strconv.ParseFloat(left, 64)
+		r, rerr := strconv.ParseFloat(right, 64)
+		if lerr == nil && rerr == nil {
+			return l < r
+		}
+		return left < right
+	})
+	return nil
+}
+
+// Render formats the table, with or without its header line
+func (t *Table) Render(headers bool) string {
+	tableString := &strings.Builder{}
+	table := tablewriter.NewWriter(tableString)
+	// must be set before the header for it to have effect on fields
+	table.SetAutoWrapText(false)
+	if headers {
+		names := make([]string, 0, len(t.columns))
+		for _, c := range t.columns {
+			names = append(names, c.Header)
+		}
+		table.SetHeader(names)
+	}
+	table.AppendBulk(t.rows)
+	FormatTable(table)
+
+	table.Render()
+	// Remove trailing \n and HT, of the table and of each line
+	lines := strings.Split(string(regexp.MustCompile(`[\n\x09][\n\x09]*$`).ReplaceAll([]byte(tableString.String()), []byte(""))), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RenderText formats the table as tab separated lines, with or without its
+// header line, the columns not being aligned. The other lines of multi-line
+// cells are indented by tabs up to their column.
+func (t *Table) RenderText(headers bool) string {
+	var lines []string
+	if headers {
+		names := make([]string, 0, len(t.columns))
+		for _, c := range t.columns {
+			names = append(names, c.Header)
+		}
+		lines = append(lines, strings.Join(names, "\t"))
+	}
+	for _, row := range t.rows {
+		cells := make([]string, 0, len(row))
+		for i, c := range row {
+			cells = append(cells, strings.ReplaceAll(c, "\n", "\n"+strings.Repeat("\t", i)))
+		}
+		lines = append(lines, strings.Join(cells, "\t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
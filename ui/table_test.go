@@ -0,0 +1,87 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/squarescale/squarescale-cli/ui"
+)
+
+func newNodeTable() *ui.Table {
+	table := ui.NewTable("Name", "Node type", "Size")
+	table.Append("node-b", "t2.small", "10")
+	table.Append("node-a", "t2.large", "9")
+	return table
+}
+
+func TestTable(t *testing.T) {
+	t.Run("Test select columns", SelectColumns)
+	t.Run("Test sort by numeric column", SortByNumericColumn)
+	t.Run("Test unknown column", UnknownColumn)
+	t.Run("Test render text", RenderText)
+}
+
+func SelectColumns(t *testing.T) {
+	// given
+	table := newNodeTable()
+
+	// when
+	err := table.SelectColumns([]string{"size", "name"})
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	expected := "10\tnode-b\n9 \tnode-a"
+	if out := table.Render(false); out != expected {
+		t.Fatalf("Expect `%q`, got `%q`", expected, out)
+	}
+}
+
+func SortByNumericColumn(t *testing.T) {
+	// given
+	table := newNodeTable()
+
+	// when
+	err := table.SortBy("size")
+
+	// then
+	if err != nil {
+		t.Fatalf("Expect no error, got `%s`", err)
+	}
+
+	table.SelectColumns([]string{"name"})
+	expected := "node-a\nnode-b"
+	if out := table.Render(false); out != expected {
+		t.Fatalf("Expect `%q`, got `%q`", expected, out)
+	}
+}
+
+func UnknownColumn(t *testing.T) {
+	// given
+	table := newNodeTable()
+
+	// when
+	err := table.SortBy("zone")
+
+	// then
+	expected := "Unknown column: zone. Correct values are name, node-type, size"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expect error `%s`, got `%v`", expected, err)
+	}
+}
+
+func RenderText(t *testing.T) {
+	// given
+	table := newNodeTable()
+	table.Append("node-c", "t2.micro", "1\n2")
+
+	// when
+	out := table.RenderText(true)
+
+	// then
+	expected := "Name\tNode type\tSize\nnode-b\tt2.small\t10\nnode-a\tt2.large\t9\nnode-c\tt2.micro\t1\n\t\t2"
+	if out != expected {
+		t.Fatalf("Expect `%q`, got `%q`", expected, out)
+	}
+}